@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is a JSON or YAML configuration file loaded by LoadConfig. Its sections are bound to the structs
+// annotated by @config, and it is the configuration source of the @value annotations, whose keys are the dotted
+// paths of the values in the file, e.g. "database.url".
+// ConfigFile 是通过LoadConfig加载的JSON或者YAML配置文件，其中的配置段会被绑定到@config注解的结构体，
+// 它同时也是@value注解的配置源，配置项的key是它们在文件中以点分隔的路径，比如"database.url"
+type ConfigFile struct {
+	Path   string // Path is the path of the configuration file.
+	values map[string]any
+}
+
+// LoadConfig loads the JSON or YAML configuration file at the path, according to its extension, into the container.
+// The file is also set as the configuration source of the container.
+// The providers bound to the file are created lazily, so LoadConfig must be called before they are provided.
+// LoadConfig 根据扩展名将path指定的JSON或者YAML配置文件加载到容器中，该文件同时也会被设置为容器的配置源。
+// 绑定到配置文件的provider是延迟创建的，所以必须在获取它们之前调用LoadConfig
+func (c *Container) LoadConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &ConfigError{Path: path, Err: err}
+	}
+	config, err := decodeConfig(path, data)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config = config
+	c.source = config
+	return nil
+}
+
+// decodeConfig decodes the content of the configuration file at the path according to its extension.
+// decodeConfig 根据扩展名解码path指定的配置文件的内容
+func decodeConfig(path string, data []byte) (*ConfigFile, error) {
+	values := make(map[string]any)
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		// The numbers are kept as they are written, so that large integers are not converted to floats.
+		// 数字保持原样，以免大整数被转换为浮点数
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	default:
+		err = errors.New("unsupported format, only JSON and YAML are supported")
+	}
+	if err != nil {
+		return nil, &ConfigError{Path: path, Err: err}
+	}
+	return &ConfigFile{Path: path, values: values}, nil
+}
+
+// loadedConfig returns the configuration file loaded into the container or its parents, nil if none is loaded.
+// loadedConfig 返回加载到容器或者其父容器中的配置文件，没有加载则返回nil
+func (c *Container) loadedConfig() *ConfigFile {
+	for s := c; s != nil; s = s.parent {
+		s.mu.RLock()
+		config := s.config
+		s.mu.RUnlock()
+		if config != nil {
+			return config
+		}
+	}
+	return nil
+}
+
+// lookup returns the value or the section at the dotted key.
+// lookup 返回以点分隔的key对应的值或者配置段
+func (f *ConfigFile) lookup(key string) (any, bool) {
+	var node any = f.values
+	for _, name := range strings.Split(key, ".") {
+		section, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = section[name]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// Lookup returns the raw value at the dotted key, the elements of a list are separated by commas.
+// It returns false if the key is missing or is a section.
+// Lookup 返回以点分隔的key对应的原始值，列表的元素以逗号分隔。如果key不存在或者是一个配置段则返回false
+func (f *ConfigFile) Lookup(key string) (string, bool) {
+	node, ok := f.lookup(key)
+	if !ok {
+		return "", false
+	}
+	return configString(node)
+}
+
+// configString converts a value of the configuration file into the raw value, it returns false for a section.
+// configString 将配置文件中的值转换为原始值，如果是配置段则返回false
+func configString(node any) (string, bool) {
+	switch value := node.(type) {
+	case map[string]any:
+		return "", false
+	case []any:
+		fields := make([]string, len(value))
+		for i, elem := range value {
+			field, ok := configString(elem)
+			if !ok {
+				return "", false
+			}
+			fields[i] = field
+		}
+		return strings.Join(fields, ","), true
+	case nil:
+		return "", true
+	}
+	return fmt.Sprint(node), true
+}
+
+// BindConfig populates the struct base, or the struct base points to, with the section of the configuration file
+// loaded into the container c at the dotted prefix, and returns it. The fields are bound as follows:
+//
+//   - A field is bound to the key named by its config tag, e.g. `config:"url"`, or to its name case-insensitively.
+//     A field tagged with `config:"-"` is ignored.
+//   - If the key is missing, the default tag is used, e.g. `default:"localhost:3306"`, otherwise the field keeps
+//     the value of base, or an error is returned if it is tagged as required, e.g. `config:"url,required"`.
+//   - The values are converted in the same way as ValueFrom, a list can be bound to a slice as well,
+//     and a nested struct is bound to the nested section.
+//
+// It returns a *ConfigError naming the file path and the dotted key if a field cannot be bound.
+// BindConfig 使用容器c中加载的配置文件中以点分隔的prefix对应的配置段填充结构体base或者base指向的结构体，并将其返回。
+// 字段的绑定规则如下：字段绑定到config标签指定的key，比如`config:"url"`，没有标签则不区分大小写地绑定到字段名，
+// 标签为`config:"-"`的字段会被忽略；如果key不存在则使用default标签指定的默认值，比如`default:"localhost:3306"`，
+// 否则字段保持base中的值，如果字段被标记为必需的，比如`config:"url,required"`，则返回错误；
+// 配置值以和ValueFrom相同的方式转换，列表也可以绑定到切片，嵌套的结构体会绑定到嵌套的配置段。
+// 如果字段无法绑定，则返回一个包含文件路径和以点分隔的key的*ConfigError
+func BindConfig[T any](c *Container, prefix string, base T) (T, error) {
+	config := c.loadedConfig()
+	if config == nil {
+		return base, fmt.Errorf("failed to bind config %s: %w", prefix, ErrConfigNotLoaded)
+	}
+
+	value := reflect.ValueOf(&base).Elem()
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return base, &ConfigError{Path: config.Path, Key: prefix, Err: fmt.Errorf("cannot bind to %s, which is not a struct", value.Type())}
+	}
+
+	section, _ := config.lookup(prefix)
+	if err := config.bindStruct(value, section, prefix); err != nil {
+		return base, err
+	}
+	return base, nil
+}
+
+// bindStruct populates the fields of the struct value with the section at the key.
+// bindStruct 使用key对应的配置段填充结构体的字段
+func (f *ConfigFile) bindStruct(value reflect.Value, node any, key string) error {
+	section, ok := node.(map[string]any)
+	if node != nil && !ok {
+		return &ConfigError{Path: f.Path, Key: key, Err: errors.New("not a section")}
+	}
+
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("config")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if len(name) == 0 {
+			name = field.Name
+		}
+		fieldKey := name
+		if len(key) > 0 {
+			fieldKey = key + "." + name
+		}
+
+		child, ok := lookupField(section, name)
+		if !ok {
+			if def, hasDefault := field.Tag.Lookup("default"); hasDefault {
+				child, ok = def, true
+			}
+		}
+		if !ok {
+			if opts == "required" {
+				return &ConfigError{Path: f.Path, Key: fieldKey, Err: ErrValueNotFound}
+			}
+			// The nested structs are still bound, so that their defaults are applied.
+			// 嵌套的结构体仍然需要绑定，以便使用它们的默认值
+			if field.Type.Kind() == reflect.Struct {
+				if err := f.bindStruct(value.Field(i), nil, fieldKey); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := f.bindValue(value.Field(i), child, fieldKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupField returns the value of the section with the name, or with the name case-insensitively.
+// lookupField 返回配置段中指定名字的值，找不到则不区分大小写地进行查找
+func lookupField(section map[string]any, name string) (any, bool) {
+	if value, ok := section[name]; ok {
+		return value, true
+	}
+	for key, value := range section {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// bindValue sets the value at the key of the configuration file to the field.
+// bindValue 将配置文件中key对应的值设置到字段中
+func (f *ConfigFile) bindValue(field reflect.Value, node any, key string) error {
+	if field.Kind() == reflect.Struct {
+		return f.bindStruct(field, node, key)
+	}
+
+	// A list is bound to a slice element by element.
+	// 列表会逐个元素地绑定到切片
+	if list, ok := node.([]any); ok && field.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(field.Type(), len(list), len(list))
+		for i, elem := range list {
+			if err := f.bindValue(slice.Index(i), elem, fmt.Sprintf("%s.%d", key, i)); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	raw, ok := configString(node)
+	if !ok {
+		return &ConfigError{Path: f.Path, Key: key, Err: fmt.Errorf("cannot convert a section to %s", field.Type())}
+	}
+	converted, err := convertValue(raw, field.Type())
+	if err != nil {
+		return &ConfigError{Path: f.Path, Key: key, Err: fmt.Errorf("cannot convert %q to %s: %w", raw, field.Type(), err)}
+	}
+	field.Set(converted)
+	return nil
+}
+
+// LoadConfig loads the JSON or YAML configuration file at the path into the default container.
+func LoadConfig(path string) error {
+	return defaultContainer.LoadConfig(path)
+}
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPoolConfig struct {
+	Size int `config:"size"`
+}
+
+type testDbConfig struct {
+	Url     string        `config:"url,required"`
+	Timeout time.Duration `config:"timeout" default:"5s"`
+	Hosts   []string      `config:"hosts"`
+	Ports   []int         `config:"ports"`
+	Debug   bool
+	Pool    testPoolConfig `config:"pool"`
+	Ignored string         `config:"-"`
+}
+
+func writeConfig(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestBindConfig(t *testing.T) {
+	yamlPath := writeConfig(t, "app.yaml", `
+database:
+  url: mysql:3306
+  hosts: [a, b]
+  ports: "1,2"
+  debug: true
+  pool:
+    size: 10
+  ignored: value
+`)
+	jsonPath := writeConfig(t, "app.json", `{
+	"database": {"url": "mysql:3306", "timeout": "1m", "hosts": ["a", "b"], "ports": [1, 2], "Debug": true, "pool": {"size": 10}}
+}`)
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		c := NewContainer()
+		assert.NoError(t, c.LoadConfig(path))
+
+		config, err := BindConfig(c, "database", &testDbConfig{Ignored: "keep"})
+		assert.NoError(t, err)
+		assert.Equal(t, "mysql:3306", config.Url)
+		assert.Equal(t, []string{"a", "b"}, config.Hosts)
+		assert.Equal(t, []int{1, 2}, config.Ports)
+		assert.True(t, config.Debug)
+		assert.Equal(t, 10, config.Pool.Size)
+		assert.Equal(t, "keep", config.Ignored)
+
+		// The file is the configuration source of the values as well
+		size, err := ValueFrom[int](c, "database.pool.size")
+		assert.NoError(t, err)
+		assert.Equal(t, 10, size)
+		hosts, err := ValueFrom[[]string](c, "database.hosts")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, hosts)
+	}
+
+	// The default is used if the key is missing
+	c := NewContainer()
+	assert.NoError(t, c.LoadConfig(yamlPath))
+	config, err := BindConfig(c, "database", testDbConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, config.Timeout)
+
+	// The child scopes bind the file loaded into their parents
+	scoped, err := BindConfig(c.NewScope(), "database.pool", testPoolConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, scoped.Size)
+}
+
+func TestBindConfig_Error(t *testing.T) {
+	c := NewContainer()
+	_, err := BindConfig(c, "database", testDbConfig{})
+	assert.True(t, errors.Is(err, ErrConfigNotLoaded))
+
+	path := writeConfig(t, "app.yaml", `
+database:
+  timeout: soon
+server:
+  port: 8080
+`)
+	assert.NoError(t, c.LoadConfig(path))
+
+	// The required field is missing
+	_, err = BindConfig(c, "server", testDbConfig{})
+	var configErr *ConfigError
+	assert.True(t, errors.As(err, &configErr))
+	assert.Equal(t, path, configErr.Path)
+	assert.Equal(t, "server.url", configErr.Key)
+	assert.True(t, errors.Is(err, ErrValueNotFound))
+	assert.EqualError(t, err, "config file "+path+": server.url: value not found")
+
+	// The value cannot be converted
+	type timeoutConfig struct {
+		Timeout time.Duration
+	}
+	_, err = BindConfig(c, "database", timeoutConfig{})
+	assert.EqualError(t, err, "config file "+path+": database.Timeout: cannot convert \"soon\" to time.Duration: time: invalid duration \"soon\"")
+
+	_, err = BindConfig(c, "database", "not a struct")
+	assert.ErrorContains(t, err, "which is not a struct")
+
+	err = c.LoadConfig(writeConfig(t, "app.toml", ""))
+	assert.ErrorContains(t, err, "unsupported format")
+
+	err = c.LoadConfig(writeConfig(t, "app.json", "{"))
+	assert.True(t, errors.As(err, &configErr))
+}
+
+func TestBindConfig_Lazy(t *testing.T) {
+	c := NewContainer()
+	assert.NoError(t, c.RegisterLazy("config.database", func(c *Container) (any, error) {
+		return BindConfig(c, "database", &testDbConfig{})
+	}))
+	assert.NoError(t, c.RegisterLazy("main.db", func(c *Container) (any, error) {
+		config, err := ProvideFrom[*testDbConfig](c, "config.database")
+		if err != nil {
+			return nil, err
+		}
+		return "db:" + config.Url, nil
+	}))
+
+	// The error is not cached until the configuration file is loaded
+	_, err := c.Provide("main.db")
+	assert.True(t, errors.Is(err, ErrConfigNotLoaded))
+	assert.NoError(t, c.LoadConfig(writeConfig(t, "app.yaml", `
+database:
+  url: mysql:3306
+`)))
+	db, err := c.Provide("main.db")
+	assert.NoError(t, err)
+	assert.Equal(t, "db:mysql:3306", db)
+
+	// The other errors are cached
+	assert.NoError(t, c.RegisterLazy("config.server", func(c *Container) (any, error) {
+		return BindConfig(c, "server", &testDbConfig{})
+	}))
+	_, err = c.Provide("config.server")
+	assert.True(t, errors.Is(err, ErrValueNotFound))
+	assert.NoError(t, c.LoadConfig(writeConfig(t, "server.yaml", `
+server:
+  url: 0.0.0.0:8080
+`)))
+	_, err = c.Provide("config.server")
+	assert.True(t, errors.Is(err, ErrValueNotFound))
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+// Package debughttp provides an http.Handler which exposes the live object graph of a digo container,
+// e.g. to be mounted on an admin port for diagnosing the wiring in production.
+// debughttp 包提供了一个展示digo容器中对象图的http.Handler，比如可以挂载到管理端口上，用于在生产环境中诊断依赖注入
+package debughttp
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/werbenhu/digo"
+)
+
+// Edge represents that the provider From depends on the provider To.
+// Edge 表示provider From依赖于provider To
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph is the object graph of a container served by the handler.
+// Graph 是handler返回的容器的对象图
+type Graph struct {
+	Providers []digo.Metadata     `json:"providers"` // Providers are the metadata of the providers and the group members.
+	Pending   []digo.Metadata     `json:"pending"`   // Pending are the metadata of the providers of the profiles which are not activated yet.
+	Groups    map[string][]string `json:"groups"`    // Groups are the members of each group, named by their IDs or their qualified constructors.
+	Edges     []Edge              `json:"edges"`     // Edges are the dependencies between the providers.
+	Errors    []string            `json:"errors"`    // Errors are the failures recorded when initializing the container.
+}
+
+// NewGraph builds the object graph of the container c from the metadata registered into it.
+// It does not activate the profile of c, so the profile can still be set afterwards.
+// NewGraph 根据容器c中注册的元数据构建对象图，它不会激活c的profile，所以之后仍然可以设置profile
+func NewGraph(c *digo.Container) *Graph {
+	graph := &Graph{
+		Providers: c.Registered(),
+		Pending:   c.Pending(),
+		Groups:    make(map[string][]string),
+		Edges:     make([]Edge, 0),
+		Errors:    make([]string, 0),
+	}
+
+	names := make([]string, len(graph.Providers))
+	for i, meta := range graph.Providers {
+		name := nodeName(meta)
+		names[i] = name
+		for _, group := range meta.Groups {
+			graph.Groups[group] = append(graph.Groups[group], name)
+		}
+		for _, dep := range meta.Dependencies {
+			graph.Edges = append(graph.Edges, Edge{From: name, To: dep})
+		}
+	}
+
+	// A provider which is injected with a group depends on every member of the group.
+	// 注入了组的provider依赖于组的每一个成员
+	for i, meta := range graph.Providers {
+		for _, group := range meta.GroupDependencies {
+			for _, member := range graph.Groups[group] {
+				graph.Edges = append(graph.Edges, Edge{From: names[i], To: member})
+			}
+		}
+	}
+	for _, err := range c.Errors() {
+		graph.Errors = append(graph.Errors, err.Error())
+	}
+	return graph
+}
+
+// nodeName returns the name of a provider in the graph, its ID, or its constructor qualified by the package for an
+// anonymous group member, since the constructors of different packages may have the same name.
+// nodeName 返回provider在对象图中的名字，即它的ID，对于匿名的组成员则是带包名的构造函数，因为不同包的构造函数可能同名
+func nodeName(meta digo.Metadata) string {
+	if len(meta.Id) > 0 {
+		return meta.Id
+	}
+	return meta.Package + "." + meta.Constructor
+}
+
+// handler serves the object graph of a container.
+type handler struct {
+	container *digo.Container
+}
+
+// NewHandler returns an http.Handler which serves the object graph of the container c. The graph is served as JSON
+// if the request accepts application/json or has the query format=json, otherwise as a simple HTML page.
+// NewHandler 返回一个展示容器c的对象图的http.Handler，如果请求接受application/json或者带有format=json参数，
+// 则返回JSON，否则返回一个简单的HTML页面
+func NewHandler(c *digo.Container) http.Handler {
+	return &handler{container: c}
+}
+
+// Handler returns an http.Handler which serves the object graph of the default container.
+// Handler 返回一个展示默认容器的对象图的http.Handler
+func Handler() http.Handler {
+	return NewHandler(digo.Default())
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	graph := NewGraph(h.container)
+	if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(graph); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := page.Execute(w, graph); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// page is the template of the HTML page.
+var page = template.Must(template.New("digo").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>digo</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+.errors { color: #c00; }
+</style>
+</head>
+<body>
+<h1>digo</h1>
+{{if .Errors}}
+<h2>Errors</h2>
+<ul class="errors">
+{{range .Errors}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}
+<h2>Providers</h2>
+<table>
+<tr><th>ID</th><th>Type</th><th>Concrete type</th><th>Constructor</th><th>Package</th><th>Scope</th><th>Dependencies</th><th>Groups</th></tr>
+{{range .Providers}}<tr><td>{{.Id}}</td><td>{{.Type}}</td><td>{{.ConcreteType}}</td><td>{{.Constructor}}</td><td>{{.Package}}</td><td>{{.Scope}}{{if .Lazy}} (lazy){{end}}{{if .Profile}} (profile {{.Profile}}){{end}}</td><td>{{range .Dependencies}}{{.}}<br>{{end}}</td><td>{{range .Groups}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+{{if .Pending}}
+<h2>Pending profiles</h2>
+<table>
+<tr><th>ID</th><th>Type</th><th>Constructor</th><th>Package</th><th>Profile</th><th>Dependencies</th></tr>
+{{range .Pending}}<tr><td>{{.Id}}</td><td>{{.Type}}</td><td>{{.Constructor}}</td><td>{{.Package}}</td><td>{{.Profile}}</td><td>{{range .Dependencies}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Groups</h2>
+<table>
+<tr><th>Group</th><th>Members</th></tr>
+{{range $group, $members := .Groups}}<tr><td>{{$group}}</td><td>{{range $members}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
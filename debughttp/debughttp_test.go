@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package debughttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/werbenhu/digo"
+)
+
+func newTestContainer() *digo.Container {
+	c := digo.NewContainer()
+	c.Describe(digo.Metadata{Id: "main.app", Type: "*App", Constructor: "NewApp", Package: "github.com/xxx/app",
+		Scope: digo.ScopeSingleton, Dependencies: []string{"main.db", "main.redis"}})
+	c.RegisterSingleton("main.app", "app")
+	c.Describe(digo.Metadata{Constructor: "NewUserController", Package: "github.com/xxx/app",
+		Dependencies: []string{"main.db"}, Groups: []string{"controllers"}})
+	c.RegisterMember("controllers", "user")
+	c.Fail("main.db", errors.New("db url is empty"))
+	return c
+}
+
+func TestNewGraph(t *testing.T) {
+	graph := NewGraph(newTestContainer())
+
+	assert.Len(t, graph.Providers, 3)
+	assert.Equal(t, map[string][]string{"controllers": {"github.com/xxx/app.NewUserController"}}, graph.Groups)
+	assert.Equal(t, []Edge{
+		{From: "main.app", To: "main.db"},
+		{From: "main.app", To: "main.redis"},
+		{From: "github.com/xxx/app.NewUserController", To: "main.db"},
+	}, graph.Edges)
+	assert.Equal(t, []string{"failed to create main.db: db url is empty"}, graph.Errors)
+
+	// A provider injected with a group depends on every member of the group
+	c := newTestContainer()
+	c.Describe(digo.Metadata{Id: "main.router", GroupDependencies: []string{"controllers"}})
+	graph = NewGraph(c)
+	assert.Contains(t, graph.Edges, Edge{From: "main.router", To: "github.com/xxx/app.NewUserController"})
+
+	// The anonymous members are qualified by their packages, so the constructors of the same name do not collide
+	c.Describe(digo.Metadata{Constructor: "NewUserController", Package: "github.com/xxx/admin",
+		Dependencies: []string{"main.redis"}, Groups: []string{"controllers"}})
+	c.RegisterMember("controllers", "admin")
+	graph = NewGraph(c)
+	assert.Equal(t, []string{"github.com/xxx/app.NewUserController", "github.com/xxx/admin.NewUserController"},
+		graph.Groups["controllers"])
+	assert.Contains(t, graph.Edges, Edge{From: "github.com/xxx/admin.NewUserController", To: "main.redis"})
+	assert.Contains(t, graph.Edges, Edge{From: "main.router", To: "github.com/xxx/admin.NewUserController"})
+}
+
+func TestNewGraph_Pending(t *testing.T) {
+	c := newTestContainer()
+	c.Describe(digo.Metadata{Id: "main.mailer", Constructor: "NewFakeMailer", Profile: "dev"})
+	c.RegisterProfile("dev", func(c *digo.Container) {
+		c.RegisterSingleton("main.mailer", "fake mailer")
+	})
+
+	// The providers of the profile are pending, and serving the graph does not activate the profile
+	graph := NewGraph(c)
+	assert.Len(t, graph.Providers, 3)
+	assert.Equal(t, []digo.Metadata{{Id: "main.mailer", Constructor: "NewFakeMailer", Profile: "dev"}}, graph.Pending)
+
+	rec := httptest.NewRecorder()
+	NewHandler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/digo", nil))
+	assert.Contains(t, rec.Body.String(), "<td>main.mailer</td><td></td><td>NewFakeMailer</td><td></td><td>dev</td>")
+	assert.NoError(t, c.SetProfile("dev"))
+
+	mailer, err := c.Provide("main.mailer")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake mailer", mailer)
+	graph = NewGraph(c)
+	assert.Len(t, graph.Providers, 4)
+	assert.Empty(t, graph.Pending)
+}
+
+func TestHandler_JSON(t *testing.T) {
+	h := NewHandler(newTestContainer())
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/debug/digo?format=json", nil),
+		func() *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/debug/digo", nil)
+			req.Header.Set("Accept", "application/json")
+			return req
+		}(),
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+
+		var graph Graph
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &graph))
+		assert.Equal(t, "main.app", graph.Providers[0].Id)
+		assert.Equal(t, "string", graph.Providers[0].ConcreteType)
+		assert.Len(t, graph.Edges, 3)
+	}
+}
+
+func TestHandler_HTML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(newTestContainer()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/digo", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<td>main.app</td><td>*App</td>")
+	assert.Contains(t, rec.Body.String(), "failed to create main.db: db url is empty")
+	assert.Contains(t, rec.Body.String(), "<td>controllers</td><td>github.com/xxx/app.NewUserController<br></td>")
+
+	rec = httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/digo", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
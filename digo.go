@@ -4,44 +4,567 @@
 
 package digo
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Factory creates an object, resolving the dependencies of the object from the container c.
+// Factory 创建一个对象，对象的依赖从容器c中获取
+type Factory func(c *Container) (any, error)
 
-var (
-	singletons = make(map[string]any)   // Map to store singleton objects by their IDs.
-	groups     = make(map[string][]any) // Map to store groups of objects by their group IDs.
+// Scope represents how many objects a provider creates.
+// Scope 表示一个provider会创建多少个对象
+type Scope string
+
+const (
+	// ScopeSingleton indicates that the provider creates only one object, which is shared by everyone.
+	ScopeSingleton Scope = "singleton"
+	// ScopePrototype indicates that the provider creates a new object every time it is provided.
+	ScopePrototype Scope = "prototype"
+	// ScopeRequest indicates that the provider creates one object for each child scope of the container.
+	ScopeRequest Scope = "request"
 )
 
-// RegisterSingleton registers a singleton object with the provided ID.
-func RegisterSingleton(id string, object any) {
-	singletons[id] = object
+// entry represents a provider registered into a container.
+// entry 表示注册到容器中的一个provider
+type entry struct {
+	id       string
+	scope    Scope
+	factory  Factory     // factory creates the object on the first use, nil if the object is registered directly.
+	mu       sync.Mutex  // mu serializes the calls of the factory of a lazy singleton.
+	called   bool        // called indicates that the result of the factory is reused, guarded by mu.
+	done     atomic.Bool // done indicates that the factory has created the object successfully.
+	member   bool        // member indicates that the object is a group member, whose id is the group ID.
+	fallback bool        // fallback indicates that the provider yields to any other provider registered with the same ID.
+	pkg      string      // pkg is the package which registered the provider.
+	object   any
+	err      error
 }
 
-// RegisterMember registers a member object with the provided group ID.
-func RegisterMember(groupId string, object any) {
-	members, ok := groups[groupId]
+// get returns the object of the provider. If the provider is lazy, the object is created by the factory
+// exactly once, concurrent callers wait until the object is created, and the object is tracked by the container c
+// so that it can be disposed when c is closed. The error ErrConfigNotLoaded is not reused, so the object is created
+// again once the configuration file is loaded.
+// If the provider is a prototype, a new object is created by the factory every time.
+// get 返回provider的对象，如果provider是延迟创建的，对象只会由factory创建一次，并发的调用者会等待对象创建完成，
+// 创建的对象会被容器c记录下来，以便在关闭c的时候释放它。ErrConfigNotLoaded错误不会被复用，因此加载配置文件之后会重新创建对象。
+// 如果provider是原型，每次都会由factory创建一个新的对象
+func (e *entry) get(c *Container) (any, error) {
+	if e.factory == nil {
+		return e.object, e.err
+	}
+	if e.scope == ScopePrototype {
+		object, err := e.factory(c)
+		if err != nil {
+			return nil, &ProviderError{Id: e.id, Err: err}
+		}
+		return object, nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.called {
+		object, err := e.factory(c)
+		if errors.Is(err, ErrConfigNotLoaded) {
+			return nil, &ProviderError{Id: e.id, Err: err}
+		}
+		e.called = true
+		if err != nil {
+			e.err = &ProviderError{Id: e.id, Err: err}
+		} else {
+			e.object = object
+			e.done.Store(true)
+			c.track(e)
+		}
+	}
+	return e.object, e.err
+}
+
+// created returns the object of the provider if it has been created, without creating it.
+// created 返回provider已经创建的对象，不会创建新的对象
+func (e *entry) created() any {
+	if e.factory == nil || e.done.Load() {
+		return e.object
+	}
+	return nil
+}
+
+// Container is a registry of singleton objects and groups of objects.
+// A Container is safe for concurrent use by multiple goroutines.
+// Container 是单例对象和对象组的注册表，可以被多个goroutine并发使用
+type Container struct {
+	mu        sync.RWMutex
+	parent    *Container           // parent is the container which resolves the IDs unknown to a child scope.
+	closed    bool                 // closed indicates that the container has been closed.
+	providers map[string]*entry    // Map to store providers by their IDs.
+	groups    map[string][]*member // Map to store groups of objects by their group IDs.
+	created   []*entry             // created records the providers whose objects are created by the container, in creation order.
+	deps      map[string][]string  // deps records the IDs of the providers that each provider depends on.
+	groupDeps map[string][]string  // groupDeps records the IDs of the groups that each provider depends on.
+	source    ConfigSource         // source provides the configuration values injected into the providers.
+	config    *ConfigFile          // config is the configuration file loaded by LoadConfig.
+	starting  bool                 // starting indicates that Start has been called and Stop has not.
+	started   []*entry             // started records the providers whose objects are started, in starting order.
+	metas     []Metadata           // metas records the metadata described by the generated code, in the order they were described.
+	errs      []error              // errs records the failures of the generated initialization functions, in registration order.
+	broken    map[string][]error   // broken records the failures of group members by their group IDs.
+
+	profile    string     // profile is the active profile, set by SetProfile or resolved when it is activated.
+	profileSet bool       // profileSet indicates that the profile is set by SetProfile.
+	activated  bool       // activated indicates that the profile is activated, the registrations of other profiles are dropped.
+	activation sync.Once  // activation registers the pending providers of the active profile exactly once.
+	pending    []profiled // pending records the registrations of the profiles deferred until the profile is activated.
+}
+
+// NewContainer creates a new empty Container.
+// Objects registered into a Container are isolated from the objects of other containers,
+// the generated Register(c) function of each package applies the package's providers to a container.
+// NewContainer 创建一个新的空容器，不同容器中的对象相互隔离，
+// 每个包生成的Register(c)函数可以将包中的provider注册到指定的容器中
+func NewContainer() *Container {
+	return &Container{
+		providers: make(map[string]*entry),
+		groups:    make(map[string][]*member),
+		broken:    make(map[string][]error),
+		deps:      make(map[string][]string),
+		groupDeps: make(map[string][]string),
+	}
+}
+
+// defaultContainer is the container used by the package-level functions and by the generated code.
+// defaultContainer 是包级函数和生成的代码所使用的默认容器
+var defaultContainer = NewContainer()
+
+// Default returns the default container, which the generated init() functions register all providers into.
+// Default 返回默认容器，生成的init()函数会将所有的provider注册到默认容器中
+func Default() *Container {
+	return defaultContainer
+}
+
+// callerPackage returns the import path of the package of the function which called the registration function,
+// skip is the number of the stack frames to skip, 0 identifying the caller of callerPackage.
+// callerPackage 返回调用注册函数的函数所在的包路径，skip是需要跳过的栈帧数，0表示callerPackage的调用者
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
 	if !ok {
-		members = make([]any, 0)
+		return "unknown"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+
+	// The function name is the package path followed by the function name, e.g. github.com/xxx/models.init_model_user
+	// 函数名由包路径和函数名组成，比如github.com/xxx/models.init_model_user
+	name := fn.Name()
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return name
+}
+
+// register registers the provider p unless a provider with the same ID is already registered into the container.
+// A fallback provider yields to the other provider of the same ID, whichever is registered first, unless the object
+// of the fallback has already been created. A duplicate registration is returned as a *DuplicateError,
+// and it is recorded so that Init reports it as well.
+// register 注册provider，如果容器中已经注册了相同ID的provider，则返回*DuplicateError，该错误同样会被记录下来由Init报告。
+// 后备provider会让位于相同ID的其他provider，不论哪个先注册，除非后备provider的对象已经被创建
+func (c *Container) register(p *entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.providers[p.id]; ok {
+		if p.fallback && !existing.fallback {
+			return nil
+		}
+		if existing.fallback && !p.fallback && !existing.done.Load() {
+			delete(c.providers, p.id)
+		}
+	}
+	if existing, ok := c.providers[p.id]; ok {
+		err := &DuplicateError{Id: p.id, Package: p.pkg, Existing: existing.pkg}
+		c.errs = append(c.errs, err)
+		return err
+	}
+	c.providers[p.id] = p
+	if p.factory == nil {
+		c.created = append(c.created, p)
 	}
-	members = append(members, object)
-	groups[groupId] = members
+	return nil
+}
+
+// RegisterSingleton registers a singleton object with the provided ID.
+// The object is owned by the container from now on, and it is disposed when the container is closed.
+// It returns a *DuplicateError if the ID is already registered, use Replace to replace a provider explicitly.
+func (c *Container) RegisterSingleton(id string, object any) error {
+	return c.register(&entry{id: id, scope: ScopeSingleton, object: object, pkg: callerPackage(1)})
+}
+
+// RegisterLazy registers a lazy singleton with the provided ID. The object is not created until the first
+// time it is provided, then the factory is called exactly once and its result, including the error, is reused,
+// except ErrConfigNotLoaded, which is returned until the configuration file is loaded by LoadConfig.
+// RegisterLazy 注册一个延迟创建的单例，直到第一次被获取时才会调用factory创建对象，
+// factory只会被调用一次，之后都会复用它的结果，包括返回的错误，但ErrConfigNotLoaded除外，它会一直返回直到通过LoadConfig加载配置文件
+// It returns a *DuplicateError if the ID is already registered.
+func (c *Container) RegisterLazy(id string, factory Factory) error {
+	return c.register(&entry{id: id, scope: ScopeSingleton, factory: factory, pkg: callerPackage(1)})
+}
+
+// RegisterPrototype registers a prototype with the provided ID. The factory is called to create a new object
+// every time the prototype is provided, so every caller and every injection site gets a fresh object.
+// RegisterPrototype 注册一个原型，每次获取原型时都会调用factory创建一个新的对象，
+// 因此每个调用者和每个注入的地方获取到的都是新的对象
+// It returns a *DuplicateError if the ID is already registered.
+func (c *Container) RegisterPrototype(id string, factory Factory) error {
+	return c.register(&entry{id: id, scope: ScopePrototype, factory: factory, pkg: callerPackage(1)})
+}
+
+// RegisterFallback registers a fallback provider of the scope with the provided ID, which is created lazily by the
+// factory like RegisterLazy, RegisterPrototype or RegisterScoped. The fallback yields to any other provider
+// registered with the ID, before or after it, so a library can offer a default implementation which an application
+// generated separately overrides. It returns a *DuplicateError if another fallback is registered with the ID,
+// and the other provider gets it if the object of the fallback has already been created.
+// RegisterFallback 注册指定作用域和ID的后备provider，它像RegisterLazy、RegisterPrototype或者RegisterScoped一样由factory延迟创建。
+// 后备provider会让位于该ID的其他provider，不论它们在之前还是之后注册，因此库可以提供默认的实现，由单独生成代码的应用覆盖。
+// 如果该ID已经注册了其他的后备provider则返回*DuplicateError，如果后备provider的对象已经被创建，其他provider注册时会得到该错误
+func (c *Container) RegisterFallback(id string, scope Scope, factory Factory) error {
+	return c.register(&entry{id: id, scope: scope, factory: factory, fallback: true, pkg: callerPackage(1)})
+}
+
+// Replace replaces the provider with the provided ID by the singleton object, whether or not the ID is registered,
+// and returns a function which restores the previous provider. It is meant for tests and explicit overrides,
+// the lazy singletons already created keep the objects they were created with, and the object is not disposed
+// when the container is closed.
+// Replace 用单例对象替换指定ID的provider，不论该ID是否已经注册，并返回一个恢复之前的provider的函数。
+// 它适用于测试和显式的覆盖，已经创建的延迟单例仍然使用创建时的对象，容器关闭时也不会释放该对象
+func (c *Container) Replace(id string, object any) (restore func()) {
+	p := &entry{id: id, scope: ScopeSingleton, object: object, pkg: callerPackage(1)}
+
+	// The providers of the active profile are registered first, so that they can be replaced as well.
+	// 首先注册当前激活的profile的provider，以便它们也可以被替换
+	c.activate()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	previous, ok := c.providers[id]
+	c.providers[id] = p
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.providers[id] != p {
+			return
+		}
+		if ok {
+			c.providers[id] = previous
+		} else {
+			delete(c.providers, id)
+		}
+	}
+}
+
+// RegisterMember registers an anonymous member object with the provided group ID.
+// The object is owned by the container from now on, and it is disposed when the container is closed.
+func (c *Container) RegisterMember(groupId string, object any) {
+	c.RegisterMemberWith(groupId, object, MemberOptions{})
+}
+
+// Fail records that the provider with the provided ID failed to be initialized, e.g. because its constructor
+// returned an error or one of its dependencies is missing. The provider is registered as broken, so providing it,
+// or any provider depending on it, reports the error together with the dependency path.
+// The generated init functions call Fail instead of panicking, the errors are reported by Init and Errors.
+// Fail 记录指定ID的provider初始化失败，例如构造函数返回了错误或者缺少依赖。该provider会被注册为失败状态，
+// 获取它或者依赖它的provider时都会返回该错误以及依赖路径。生成的初始化函数会调用Fail而不是panic，
+// 这些错误可以通过Init和Errors获取
+func (c *Container) Fail(id string, err error) {
+	failure := &ProviderError{Id: id, Err: err}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.providers[id]; !ok {
+		c.providers[id] = &entry{id: id, scope: ScopeSingleton, err: failure, pkg: callerPackage(1)}
+	}
+	c.errs = append(c.errs, failure)
+}
+
+// FailMember records that the member created by the constructor with the provided name failed to be
+// initialized, the group is reported as broken by Members, Init and Errors.
+// FailMember 记录由指定构造函数创建的组成员初始化失败，Members、Init和Errors都会报告该组的错误
+func (c *Container) FailMember(groupId string, name string, err error) {
+	failure := &ProviderError{Id: fmt.Sprintf("%s member %s", groupId, name), Err: err}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.broken[groupId] = append(c.broken[groupId], failure)
+	c.errs = append(c.errs, failure)
+}
+
+// Errors returns the failures recorded by Fail and FailMember, and the duplicate registrations,
+// in the order they were recorded.
+// Errors 返回通过Fail和FailMember记录的所有错误，按记录的顺序排列
+func (c *Container) Errors() []error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	errs := make([]error, len(c.errs))
+	copy(errs, c.errs)
+	return errs
+}
+
+// Init reports whether all providers and group members registered by the generated init functions were
+// initialized successfully, it returns all the recorded failures joined into one error, or nil.
+// The failures of lazy singletons and prototypes are returned when they are provided instead.
+// Init 检查生成的初始化函数注册的所有provider和组成员是否都初始化成功，返回合并了所有失败的错误，或者nil。
+// 延迟创建的单例和原型的错误会在获取它们时返回
+func (c *Container) Init() error {
+	return errors.Join(c.Errors()...)
 }
 
 // Members returns the group of objects associated with the provided group ID.
-// It returns an error if the group does not exist.
-func Members(name string) ([]any, error) {
-	group, ok := groups[name]
+// A child scope returns the group of its parent if the group is not registered into the scope itself.
+// The returned slice is a copy, so it is safe to use while other members are being registered.
+// It returns an error if the group does not exist or any of its members failed to be initialized.
+func (c *Container) Members(name string) ([]any, error) {
+	group, err := c.group(name)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]any, len(group))
+	for i, m := range group {
+		members[i] = m.object
+	}
+	return members, nil
+}
+
+// Provide returns the object associated with the provided ID.
+// A lazy singleton is created on the first call, together with the lazy singletons it depends on,
+// and a prototype is created on every call.
+// It returns an error if the object does not exist or fails to be created.
+func (c *Container) Provide(id string) (any, error) {
+	c.mu.RLock()
+	closed := c.closed
+	c.mu.RUnlock()
+	if closed {
+		return nil, errors.New("container is closed")
+	}
+
+	p, owner, ok := c.lookup(id)
 	if !ok {
-		return nil, errors.New("group not found")
+		return nil, &NotFoundError{Id: id, Suggestions: suggest(id, c.providerIds()), Err: ErrProviderNotFound}
 	}
-	return group, nil
+
+	// The lock must not be held while creating the object, since the factory provides its dependencies from the container.
+	// 创建对象时不能持有锁，因为factory需要从容器中获取它的依赖
+	switch p.scope {
+	case ScopePrototype:
+		// A prototype resolves its dependencies from the container it is provided from,
+		// so it can depend on the request scoped objects of a child scope.
+		// 原型从获取它的容器中获取依赖，因此它可以依赖子作用域中的请求作用域对象
+		return p.get(c)
+	case ScopeRequest:
+		if owner == c {
+			if c.parent == nil {
+				return nil, fmt.Errorf("%s is request scoped and must be provided from a scope created by NewScope", id)
+			}
+			return p.get(c)
+		}
+		return c.scoped(p).get(c)
+	}
+
+	// A singleton is created by the container it is registered into,
+	// so it never captures the request scoped objects of a child scope.
+	// 单例由注册它的容器创建，因此它不会引用子作用域中的请求作用域对象
+	return p.get(owner)
+}
+
+// lookup finds the provider with the ID in the container and its parents,
+// and returns the provider and the container which the provider is registered into.
+// The profile of a container is activated only if the ID is not registered into it yet, so the singletons
+// created in init() from the providers without a profile do not activate the profile before main() sets it.
+// lookup 从容器及其父容器中查找provider，返回provider以及注册该provider的容器。
+// 只有ID还没有注册到容器中时才会激活容器的profile，因此在init()中通过没有profile的provider创建单例时，
+// 不会在main()设置profile之前就激活profile
+func (c *Container) lookup(id string) (*entry, *Container, bool) {
+	for s := c; s != nil; s = s.parent {
+		s.mu.RLock()
+		p, ok := s.providers[id]
+		s.mu.RUnlock()
+		if !ok {
+			s.activate()
+			s.mu.RLock()
+			p, ok = s.providers[id]
+			s.mu.RUnlock()
+		}
+		if ok {
+			return p, s, true
+		}
+	}
+	return nil, nil, false
+}
+
+// providerIds returns the IDs of the providers registered into the container and its parents.
+func (c *Container) providerIds() []string {
+	ids := make([]string, 0)
+	for s := c; s != nil; s = s.parent {
+		s.mu.RLock()
+		for id := range s.providers {
+			ids = append(ids, id)
+		}
+		s.mu.RUnlock()
+	}
+	return ids
+}
+
+// groupIds returns the IDs of the groups registered into the container and its parents.
+func (c *Container) groupIds() []string {
+	ids := make([]string, 0)
+	for s := c; s != nil; s = s.parent {
+		s.mu.RLock()
+		for id := range s.groups {
+			ids = append(ids, id)
+		}
+		for id := range s.broken {
+			ids = append(ids, id)
+		}
+		s.mu.RUnlock()
+	}
+	return ids
+}
+
+// track records that the object of the provider has been created by the container.
+func (c *Container) track(p *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.created = append(c.created, p)
+}
+
+// RegisterSingleton registers a singleton object with the provided ID into the default container.
+func RegisterSingleton(id string, object any) error {
+	return defaultContainer.register(&entry{id: id, scope: ScopeSingleton, object: object, pkg: callerPackage(1)})
+}
+
+// RegisterLazy registers a lazy singleton with the provided ID into the default container.
+func RegisterLazy(id string, factory Factory) error {
+	return defaultContainer.register(&entry{id: id, scope: ScopeSingleton, factory: factory, pkg: callerPackage(1)})
+}
+
+// RegisterPrototype registers a prototype with the provided ID into the default container.
+func RegisterPrototype(id string, factory Factory) error {
+	return defaultContainer.register(&entry{id: id, scope: ScopePrototype, factory: factory, pkg: callerPackage(1)})
+}
+
+// RegisterFallback registers a fallback provider of the scope with the provided ID into the default container.
+func RegisterFallback(id string, scope Scope, factory Factory) error {
+	return defaultContainer.register(&entry{id: id, scope: scope, factory: factory, fallback: true, pkg: callerPackage(1)})
+}
+
+// Replace replaces the provider with the provided ID in the default container by the singleton object,
+// and returns a function which restores the previous provider.
+func Replace(id string, object any) (restore func()) {
+	return defaultContainer.Replace(id, object)
+}
+
+// RegisterMember registers a member object with the provided group ID into the default container.
+func RegisterMember(groupId string, object any) {
+	defaultContainer.RegisterMember(groupId, object)
+}
+
+// Members returns the group of objects associated with the provided group ID from the default container.
+// It returns an error if the group does not exist.
+func Members(name string) ([]any, error) {
+	return defaultContainer.Members(name)
 }
 
-// Provide returns the singleton object associated with the provided ID.
+// Provide returns the singleton object associated with the provided ID from the default container.
 // It returns an error if the object does not exist.
 func Provide(id string) (any, error) {
-	p, ok := singletons[id]
-	if ok {
-		return p, nil
+	return defaultContainer.Provide(id)
+}
+
+// Errors returns the failures of the generated init functions recorded in the default container.
+func Errors() []error {
+	return defaultContainer.Errors()
+}
+
+// Init reports the failures of the generated init functions recorded in the default container as one error.
+// It is meant to be called at the beginning of main, so every broken provider can be logged before exiting.
+// Init 以一个错误的形式返回默认容器中记录的生成的初始化函数的失败，建议在main的开头调用，以便在退出前记录所有失败的provider
+func Init() error {
+	return defaultContainer.Init()
+}
+
+// typeOf returns the reflect.Type of the type parameter T, which also works for interface types.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// ProvideAs returns the singleton object associated with the provided ID from the default container,
+// converted to type T. It returns a *TypeMismatchError if the object is not of type T.
+// ProvideAs 从默认容器中获取指定ID的单例对象，并将其转换为T类型
+func ProvideAs[T any](id string) (T, error) {
+	return ProvideFrom[T](defaultContainer, id)
+}
+
+// ProvideFrom returns the singleton object associated with the provided ID from the container c,
+// converted to type T. It returns a *TypeMismatchError if the object is not of type T.
+// ProvideFrom 从容器c中获取指定ID的单例对象，并将其转换为T类型
+func ProvideFrom[T any](c *Container, id string) (T, error) {
+	var zero T
+	obj, err := c.Provide(id)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := obj.(T)
+	if !ok {
+		return zero, &TypeMismatchError{Id: id, Expected: typeOf[T](), Actual: reflect.TypeOf(obj)}
+	}
+	return typed, nil
+}
+
+// ProvideOptional returns the singleton object associated with the provided ID from the default container,
+// converted to type T, or the zero value of T if no provider is registered with the ID.
+// ProvideOptional 从默认容器中获取指定ID的单例对象，并将其转换为T类型，如果没有注册该ID的provider则返回T的零值
+func ProvideOptional[T any](id string) (T, error) {
+	return ProvideOptionalFrom[T](defaultContainer, id)
+}
+
+// ProvideOptionalFrom returns the singleton object associated with the provided ID from the container c,
+// converted to type T, or the zero value of T if no provider is registered with the ID.
+// The other errors are returned as is, including the errors of the providers that the provider depends on.
+// ProvideOptionalFrom 从容器c中获取指定ID的单例对象，并将其转换为T类型，如果没有注册该ID的provider则返回T的零值，
+// 其他错误会原样返回，包括该provider所依赖的provider的错误
+func ProvideOptionalFrom[T any](c *Container, id string) (T, error) {
+	typed, err := ProvideFrom[T](c, id)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) && notFound.Id == id && errors.Is(notFound, ErrProviderNotFound) {
+		return typed, nil
+	}
+	return typed, err
+}
+
+// MembersOf returns the group of objects associated with the provided group ID from the default container,
+// converted to type T. It returns a *TypeMismatchError if any member is not of type T.
+// MembersOf 从默认容器中获取指定组的所有成员，并将它们转换为T类型
+func MembersOf[T any](groupId string) ([]T, error) {
+	return MembersFrom[T](defaultContainer, groupId)
+}
+
+// MembersFrom returns the group of objects associated with the provided group ID from the container c,
+// converted to type T. It returns a *TypeMismatchError if any member is not of type T.
+// MembersFrom 从容器c中获取指定组的所有成员，并将它们转换为T类型
+func MembersFrom[T any](c *Container, groupId string) ([]T, error) {
+	objs, err := c.Members(groupId)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]T, len(objs))
+	for i, obj := range objs {
+		typed, ok := obj.(T)
+		if !ok {
+			return nil, &TypeMismatchError{Id: groupId, Expected: typeOf[T](), Actual: reflect.TypeOf(obj)}
+		}
+		members[i] = typed
 	}
-	return nil, errors.New("object not found")
+	return members, nil
 }
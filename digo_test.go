@@ -5,6 +5,10 @@
 package digo
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -42,12 +46,13 @@ func TestMembers_NonexistentGroup(t *testing.T) {
 	// Check if the error is returned for a nonexistent group
 	_, err := Members(groupID)
 	assert.Error(t, err)
-	assert.EqualError(t, err, "group not found")
+	assert.EqualError(t, err, "group nonexistent not found")
+	assert.ErrorIs(t, err, ErrGroupNotFound)
 }
 
 func TestProvide(t *testing.T) {
-	id := "singleton"
-	obj := "singleton object"
+	id := "provided"
+	obj := "provided object"
 
 	RegisterSingleton(id, obj)
 
@@ -63,5 +68,365 @@ func TestProvide_NonexistentObject(t *testing.T) {
 	// Check if the error is returned for a nonexistent object
 	_, err := Provide(id)
 	assert.Error(t, err)
-	assert.EqualError(t, err, "object not found")
+	assert.EqualError(t, err, "provider nonexistent not found")
+	assert.ErrorIs(t, err, ErrProviderNotFound)
+}
+
+func TestProvide_Suggestions(t *testing.T) {
+	c := NewContainer()
+	c.RegisterSingleton("main.db", "db")
+	c.RegisterSingleton("main.db.url", "url")
+	c.RegisterSingleton("main.redis", "redis")
+	c.RegisterMember("main.controllers", "controller")
+
+	// The registered IDs similar to the requested ID are suggested
+	_, err := c.NewScope().Provide("main.dbb")
+	assert.EqualError(t, err, "provider main.dbb not found, did you mean main.db?")
+	var notFound *NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "main.dbb", notFound.Id)
+	assert.Equal(t, []string{"main.db"}, notFound.Suggestions)
+
+	_, err = c.Members("main.controller")
+	assert.EqualError(t, err, "group main.controller not found, did you mean main.controllers?")
+	assert.ErrorIs(t, err, ErrGroupNotFound)
+
+	_, err = c.Provide("other")
+	assert.EqualError(t, err, "provider other not found")
+}
+
+func TestContainer_Concurrent(t *testing.T) {
+	c := NewContainer()
+	c.RegisterSingleton("concurrent", "object")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(4)
+		go func(i int) {
+			defer wg.Done()
+			c.RegisterSingleton(fmt.Sprintf("singleton.%d", i), i)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			c.RegisterMember("concurrent", i)
+		}(i)
+		go func() {
+			defer wg.Done()
+			obj, err := c.Provide("concurrent")
+			assert.NoError(t, err)
+			assert.Equal(t, "object", obj)
+		}()
+		go func() {
+			defer wg.Done()
+			c.Members("concurrent")
+		}()
+	}
+	wg.Wait()
+
+	members, err := c.Members("concurrent")
+	assert.NoError(t, err)
+	assert.Len(t, members, 50)
+	for i := 0; i < 50; i++ {
+		obj, err := c.Provide(fmt.Sprintf("singleton.%d", i))
+		assert.NoError(t, err)
+		assert.Equal(t, i, obj)
+	}
+}
+
+type testStringer interface {
+	String() string
+}
+
+type testName string
+
+func (n testName) String() string {
+	return string(n)
+}
+
+func TestProvideAs(t *testing.T) {
+	RegisterSingleton("typed", testName("typed object"))
+
+	name, err := ProvideAs[testName]("typed")
+	assert.NoError(t, err)
+	assert.Equal(t, testName("typed object"), name)
+
+	// Interfaces implemented by the object are accepted as well
+	stringer, err := ProvideAs[testStringer]("typed")
+	assert.NoError(t, err)
+	assert.Equal(t, "typed object", stringer.String())
+
+	// A wrong type results in a *TypeMismatchError
+	_, err = ProvideAs[int]("typed")
+	var mismatch *TypeMismatchError
+	assert.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "typed", mismatch.Id)
+	assert.EqualError(t, err, "type mismatch for typed: expected int, actual digo.testName")
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+
+	_, err = ProvideAs[int]("nonexistent")
+	assert.EqualError(t, err, "provider nonexistent not found")
+}
+
+func TestProvideOptional(t *testing.T) {
+	c := NewContainer()
+	c.RegisterSingleton("optional.cache", testName("cache"))
+
+	cache, err := ProvideOptionalFrom[testStringer](c, "optional.cache")
+	assert.NoError(t, err)
+	assert.Equal(t, "cache", cache.String())
+
+	// The zero value is returned if the provider does not exist
+	cache, err = ProvideOptionalFrom[testStringer](c, "optional.nonexistent")
+	assert.NoError(t, err)
+	assert.Nil(t, cache)
+
+	// The other errors are returned, including a missing dependency of the provider
+	c.RegisterLazy("optional.db", func(c *Container) (any, error) {
+		return c.Provide("missing.url")
+	})
+	_, err = ProvideOptionalFrom[string](c, "optional.db")
+	assert.EqualError(t, err, "failed to create optional.db: provider missing.url not found")
+	_, err = ProvideOptionalFrom[int](c, "optional.cache")
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+
+	name, err := ProvideOptional[string]("optional.nonexistent")
+	assert.NoError(t, err)
+	assert.Equal(t, "", name)
+}
+
+func TestMembersOf(t *testing.T) {
+	RegisterMember("typed.group", testName("member 1"))
+	RegisterMember("typed.group", testName("member 2"))
+
+	members, err := MembersOf[testStringer]("typed.group")
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Equal(t, "member 2", members[1].String())
+
+	RegisterMember("typed.group", 3)
+	_, err = MembersOf[testStringer]("typed.group")
+	assert.EqualError(t, err, "type mismatch for typed.group: expected digo.testStringer, actual int")
+
+	_, err = MembersOf[testStringer]("nonexistent")
+	assert.EqualError(t, err, "group nonexistent not found")
+}
+
+func TestNewContainer_Isolated(t *testing.T) {
+	c1 := NewContainer()
+	c2 := NewContainer()
+
+	c1.RegisterSingleton("isolated", "object 1")
+	c2.RegisterSingleton("isolated", "object 2")
+	c1.RegisterMember("isolated.group", "member 1")
+
+	obj, err := ProvideFrom[string](c1, "isolated")
+	assert.NoError(t, err)
+	assert.Equal(t, "object 1", obj)
+
+	obj, err = ProvideFrom[string](c2, "isolated")
+	assert.NoError(t, err)
+	assert.Equal(t, "object 2", obj)
+
+	members, err := MembersFrom[string](c1, "isolated.group")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"member 1"}, members)
+
+	_, err = c2.Members("isolated.group")
+	assert.Error(t, err)
+
+	// The default container is not affected by other containers
+	_, err = Provide("isolated")
+	assert.Error(t, err)
+	assert.Same(t, defaultContainer, Default())
+	assert.NoError(t, Init())
+	assert.Empty(t, Errors())
+}
+
+func TestRegisterLazy(t *testing.T) {
+	c := NewContainer()
+	var created int32
+
+	c.RegisterLazy("lazy.db", func(c *Container) (any, error) {
+		url, err := ProvideFrom[string](c, "lazy.url")
+		if err != nil {
+			return nil, err
+		}
+		atomic.AddInt32(&created, 1)
+		return "db:" + url, nil
+	})
+	c.RegisterLazy("lazy.url", func(c *Container) (any, error) {
+		return "localhost:3306", nil
+	})
+
+	// Nothing is created before the first Provide
+	assert.Equal(t, int32(0), atomic.LoadInt32(&created))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			obj, err := c.Provide("lazy.db")
+			assert.NoError(t, err)
+			assert.Equal(t, "db:localhost:3306", obj)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&created))
+
+	// The error of the factory is reused as well
+	c.RegisterLazy("lazy.broken", func(c *Container) (any, error) {
+		return c.Provide("nonexistent")
+	})
+	_, err := c.Provide("lazy.broken")
+	assert.EqualError(t, err, "failed to create lazy.broken: provider nonexistent not found")
+	_, err = c.Provide("lazy.broken")
+	assert.EqualError(t, err, "failed to create lazy.broken: provider nonexistent not found")
+
+	// The error of a lazy dependency is reported with the whole path
+	c.RegisterLazy("lazy.app", func(c *Container) (any, error) {
+		return c.Provide("lazy.broken")
+	})
+	_, err = c.Provide("lazy.app")
+	assert.EqualError(t, err, "failed to create lazy.app: failed to create lazy.broken: provider nonexistent not found")
+	var providerErr *ProviderError
+	assert.ErrorAs(t, err, &providerErr)
+	assert.Equal(t, "lazy.app", providerErr.Id)
+}
+
+func TestRegisterPrototype(t *testing.T) {
+	c := NewContainer()
+	var created int
+
+	c.RegisterPrototype("prototype.req", func(c *Container) (any, error) {
+		created++
+		return &struct{ n int }{created}, nil
+	})
+
+	obj1, err := c.Provide("prototype.req")
+	assert.NoError(t, err)
+	obj2, err := c.Provide("prototype.req")
+	assert.NoError(t, err)
+
+	// Every call gets a fresh object from the factory
+	assert.Equal(t, 2, created)
+	assert.NotSame(t, obj1, obj2)
+}
+
+func TestContainer_Fail(t *testing.T) {
+	c := NewContainer()
+	assert.NoError(t, c.Init())
+
+	// The failures of the init functions are recorded instead of panicking
+	c.Fail("fail.db", errors.New("db url is empty"))
+	_, err := c.Provide("fail.db")
+	c.Fail("fail.app", err)
+	c.RegisterMember("fail.group", "member 1")
+	c.FailMember("fail.group", "NewController", errors.New("boom"))
+
+	// The dependents of a broken provider report the dependency path
+	_, err = c.Provide("fail.app")
+	assert.EqualError(t, err, "failed to create fail.app: failed to create fail.db: db url is empty")
+	var providerErr *ProviderError
+	assert.ErrorAs(t, err, &providerErr)
+	assert.Equal(t, []string{"fail.app", "fail.db"}, providerErr.Path())
+
+	_, err = c.Members("fail.group")
+	assert.EqualError(t, err, "failed to create fail.group member NewController: boom")
+
+	errs := c.Errors()
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "failed to create fail.db: db url is empty")
+	assert.EqualError(t, c.Init(), "failed to create fail.db: db url is empty\n"+
+		"failed to create fail.app: failed to create fail.db: db url is empty\n"+
+		"failed to create fail.group member NewController: boom")
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	c := NewContainer()
+	assert.NoError(t, c.RegisterSingleton("dup.db", "db 1"))
+
+	// A duplicate registration is an error naming both packages, and the first registration wins
+	err := c.RegisterLazy("dup.db", func(c *Container) (any, error) { return "db 2", nil })
+	assert.EqualError(t, err, "duplicate provider dup.db registered by github.com/werbenhu/digo, already registered by github.com/werbenhu/digo")
+	assert.ErrorIs(t, err, ErrDuplicateProvider)
+	var duplicate *DuplicateError
+	assert.ErrorAs(t, err, &duplicate)
+	assert.Equal(t, "dup.db", duplicate.Id)
+
+	obj, err := c.Provide("dup.db")
+	assert.NoError(t, err)
+	assert.Equal(t, "db 1", obj)
+
+	// The duplicate registration is reported by Init as well
+	assert.ErrorIs(t, c.Init(), ErrDuplicateProvider)
+}
+
+func TestRegisterFallback(t *testing.T) {
+	fallback := func(c *Container) (any, error) { return "memory cache", nil }
+
+	// The fallback yields to a provider registered after it
+	c := NewContainer()
+	assert.NoError(t, c.RegisterFallback("main.cache", ScopeSingleton, fallback))
+	assert.NoError(t, c.RegisterSingleton("main.cache", "redis cache"))
+	obj, err := c.Provide("main.cache")
+	assert.NoError(t, err)
+	assert.Equal(t, "redis cache", obj)
+
+	// and to a provider registered before it
+	c = NewContainer()
+	assert.NoError(t, c.RegisterSingleton("main.cache", "redis cache"))
+	assert.NoError(t, c.RegisterFallback("main.cache", ScopeSingleton, fallback))
+	obj, err = c.Provide("main.cache")
+	assert.NoError(t, err)
+	assert.Equal(t, "redis cache", obj)
+	assert.NoError(t, c.Init())
+
+	// The fallback is provided if no other provider is registered
+	c = NewContainer()
+	assert.NoError(t, c.RegisterFallback("main.cache", ScopePrototype, fallback))
+	obj, err = c.Provide("main.cache")
+	assert.NoError(t, err)
+	assert.Equal(t, "memory cache", obj)
+
+	// Two fallbacks of the same ID are duplicate
+	assert.ErrorIs(t, c.RegisterFallback("main.cache", ScopeSingleton, fallback), ErrDuplicateProvider)
+
+	// A fallback whose object is already created does not yield any more
+	c = NewContainer()
+	assert.NoError(t, c.RegisterFallback("main.cache", ScopeSingleton, fallback))
+	obj, err = c.Provide("main.cache")
+	assert.NoError(t, err)
+	assert.Equal(t, "memory cache", obj)
+	assert.ErrorIs(t, c.RegisterSingleton("main.cache", "redis cache"), ErrDuplicateProvider)
+	assert.ErrorIs(t, c.Init(), ErrDuplicateProvider)
+}
+
+func TestReplace(t *testing.T) {
+	c := NewContainer()
+	c.RegisterSingleton("replace.db", "db")
+
+	restore := c.Replace("replace.db", "fake db")
+	obj, err := c.Provide("replace.db")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake db", obj)
+
+	restore()
+	obj, err = c.Provide("replace.db")
+	assert.NoError(t, err)
+	assert.Equal(t, "db", obj)
+
+	// Replacing an unknown ID registers it until it is restored
+	restore = c.Replace("replace.cache", "fake cache")
+	_, err = c.Provide("replace.cache")
+	assert.NoError(t, err)
+	restore()
+	_, err = c.Provide("replace.cache")
+	assert.ErrorIs(t, err, ErrProviderNotFound)
+	assert.NoError(t, c.Init())
+}
+
+func TestCallerPackage(t *testing.T) {
+	assert.Equal(t, "github.com/werbenhu/digo", callerPackage(0))
+	assert.Equal(t, "testing", callerPackage(1))
 }
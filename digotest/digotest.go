@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+// Package digotest provides helpers for using digo containers in tests,
+// e.g. overriding providers with fakes and asserting that objects can be provided.
+// digotest 包提供了在测试中使用digo容器的辅助函数，比如用假对象覆盖provider，以及断言对象可以被获取
+package digotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/werbenhu/digo"
+)
+
+// New returns a fresh isolated container for the test, which is closed when the test and its subtests complete.
+// Apply the generated Register(c) functions of the packages under test to populate it.
+// New 返回一个新的隔离的容器，测试及其子测试结束时容器会被关闭，可以调用被测试包生成的Register(c)函数注册provider
+func New(t testing.TB) *digo.Container {
+	t.Helper()
+	c := digo.NewContainer()
+	t.Cleanup(func() {
+		if err := c.Close(context.Background()); err != nil {
+			t.Errorf("failed to close the container: %s", err)
+		}
+	})
+	return c
+}
+
+// Override replaces the provider with the ID in the default container by the object for the duration of the test,
+// the previous provider is restored when the test and its subtests complete.
+// The default container is shared, so tests overriding providers must not run in parallel.
+// Override 在测试期间用object替换默认容器中指定ID的provider，测试及其子测试结束时会恢复之前的provider，
+// 由于默认容器是共享的，覆盖provider的测试不能并行运行
+func Override(t testing.TB, id string, object any) {
+	t.Helper()
+	OverrideIn(t, digo.Default(), id, object)
+}
+
+// OverrideIn replaces the provider with the ID in the container c by the object for the duration of the test,
+// the previous provider is restored when the test and its subtests complete.
+// The lazy singletons already created keep the objects they were created with.
+// OverrideIn 在测试期间用object替换容器c中指定ID的provider，测试及其子测试结束时会恢复之前的provider，
+// 已经创建的延迟单例仍然使用创建时的对象
+func OverrideIn(t testing.TB, c *digo.Container, id string, object any) {
+	t.Helper()
+	t.Cleanup(c.Replace(id, object))
+}
+
+// RequireProvided returns the object with the ID from the default container,
+// the test fails immediately if the object cannot be provided.
+// RequireProvided 从默认容器中获取指定ID的对象，如果无法获取则测试立即失败
+func RequireProvided(t testing.TB, id string) any {
+	t.Helper()
+	return RequireProvidedFrom(t, digo.Default(), id)
+}
+
+// RequireProvidedFrom returns the object with the ID from the container c,
+// the test fails immediately if the object cannot be provided.
+// RequireProvidedFrom 从容器c中获取指定ID的对象，如果无法获取则测试立即失败
+func RequireProvidedFrom(t testing.TB, c *digo.Container, id string) any {
+	t.Helper()
+	obj, err := c.Provide(id)
+	if err != nil {
+		t.Fatalf("failed to provide %s: %s", id, err)
+	}
+	return obj
+}
+
+// RequireInit fails the test immediately if any provider of the container c failed to be initialized.
+// RequireInit 如果容器c中有provider初始化失败，测试立即失败
+func RequireInit(t testing.TB, c *digo.Container) {
+	t.Helper()
+	if err := c.Init(); err != nil {
+		t.Fatalf("failed to initialize the container: %s", err)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digotest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/werbenhu/digo"
+)
+
+type closer struct {
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	var c *digo.Container
+	conn := &closer{}
+
+	t.Run("isolated", func(t *testing.T) {
+		c = New(t)
+		c.RegisterSingleton("digotest.conn", conn)
+		assert.Same(t, conn, RequireProvidedFrom(t, c, "digotest.conn"))
+		RequireInit(t, c)
+
+		_, err := digo.Provide("digotest.conn")
+		assert.ErrorIs(t, err, digo.ErrProviderNotFound)
+	})
+
+	// The container is closed when the test completes
+	assert.True(t, conn.closed)
+	_, err := c.Provide("digotest.conn")
+	assert.Error(t, err)
+}
+
+func TestOverride(t *testing.T) {
+	digo.RegisterSingleton("digotest.db", "real db")
+
+	t.Run("override", func(t *testing.T) {
+		Override(t, "digotest.db", "fake db")
+		assert.Equal(t, "fake db", RequireProvided(t, "digotest.db"))
+	})
+
+	// The provider is restored when the test completes
+	assert.Equal(t, "real db", RequireProvided(t, "digotest.db"))
+}
+
+func TestOverrideIn(t *testing.T) {
+	c := New(t)
+
+	t.Run("override", func(t *testing.T) {
+		OverrideIn(t, c, "digotest.cache", "fake cache")
+		assert.Equal(t, "fake cache", RequireProvidedFrom(t, c, "digotest.cache"))
+	})
+
+	_, err := c.Provide("digotest.cache")
+	assert.ErrorIs(t, err, digo.ErrProviderNotFound)
+}
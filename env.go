@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"fmt"
+	"os"
+)
+
+// Environment looks up the environment variables injected into a constructor by the @env annotations,
+// and collects the errors of all the variables, so that they are reported together instead of one by one.
+// Environment 查找通过@env注解注入到构造函数中的环境变量，并收集所有环境变量的错误，以便一起报告而不是逐个报告
+type Environment struct {
+	function string
+	missing  []string
+	errs     []error
+}
+
+// NewEnvironment creates an Environment for the constructor with the provided name.
+// NewEnvironment 为指定名字的构造函数创建一个Environment
+func NewEnvironment(function string) *Environment {
+	return &Environment{function: function}
+}
+
+// LookupEnv looks up the environment variable with the name and converts it to type T, in the same way as ValueFrom.
+// If the variable is not set, the first default is used, or the zero value of T. The missing required variables
+// and the conversion errors are collected by env and reported by env.Err.
+// LookupEnv 查找指定名字的环境变量，并以和ValueFrom相同的方式将其转换为T类型。如果环境变量没有设置，
+// 则使用第一个默认值，或者T的零值。缺失的必需环境变量以及转换错误会被env收集，并通过env.Err报告
+func LookupEnv[T any](env *Environment, name string, required bool, defaults ...string) T {
+	var zero T
+	value, ok := os.LookupEnv(name)
+	if !ok && required {
+		env.missing = append(env.missing, name)
+		return zero
+	}
+	if !ok && len(defaults) == 0 {
+		return zero
+	}
+	if !ok {
+		value = defaults[0]
+	}
+
+	converted, err := convertValue(value, typeOf[T]())
+	if err != nil {
+		env.errs = append(env.errs, fmt.Errorf("invalid variable %s=%q: %w", name, value, err))
+		return zero
+	}
+	return converted.Interface().(T)
+}
+
+// Err returns an *EnvError reporting all the missing required variables and the conversion errors
+// of the constructor, nil if there is none.
+// Err 返回一个报告构造函数所有缺失的必需环境变量以及转换错误的*EnvError，如果没有错误则返回nil
+func (env *Environment) Err() error {
+	if len(env.missing) == 0 && len(env.errs) == 0 {
+		return nil
+	}
+	return &EnvError{Function: env.function, Missing: env.missing, Errs: env.errs}
+}
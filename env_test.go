@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupEnv(t *testing.T) {
+	t.Setenv("DIGO_TEST_PORT", "8080")
+	t.Setenv("DIGO_TEST_HOSTS", "a,b")
+	t.Setenv("DIGO_TEST_TIMEOUT", "5s")
+
+	env := NewEnvironment("NewServer")
+	assert.Equal(t, 8080, LookupEnv[int](env, "DIGO_TEST_PORT", true))
+	assert.Equal(t, []string{"a", "b"}, LookupEnv[[]string](env, "DIGO_TEST_HOSTS", false))
+	assert.Equal(t, 5*time.Second, LookupEnv[time.Duration](env, "DIGO_TEST_TIMEOUT", false, "1s"))
+
+	// The default or the zero value is used if an optional variable is not set
+	assert.Equal(t, "localhost", LookupEnv[string](env, "DIGO_TEST_HOST", false, "localhost"))
+	assert.Equal(t, false, LookupEnv[bool](env, "DIGO_TEST_DEBUG", false))
+	assert.NoError(t, env.Err())
+}
+
+func TestLookupEnv_Error(t *testing.T) {
+	t.Setenv("DIGO_TEST_PORT", "abc")
+
+	// All the missing variables and the conversion errors are reported together
+	env := NewEnvironment("NewServer")
+	LookupEnv[string](env, "DIGO_TEST_HOST", true)
+	LookupEnv[int](env, "DIGO_TEST_PORT", true)
+	LookupEnv[bool](env, "DIGO_TEST_DEBUG", false, "maybe")
+	LookupEnv[string](env, "DIGO_TEST_USER", true)
+
+	err := env.Err()
+	assert.EqualError(t, err, "invalid environment of NewServer: missing required variables DIGO_TEST_HOST, DIGO_TEST_USER; "+
+		`invalid variable DIGO_TEST_PORT="abc": strconv.ParseInt: parsing "abc": invalid syntax; `+
+		`invalid variable DIGO_TEST_DEBUG="maybe": strconv.ParseBool: parsing "maybe": invalid syntax`)
+	var envErr *EnvError
+	assert.ErrorAs(t, err, &envErr)
+	assert.Equal(t, []string{"DIGO_TEST_HOST", "DIGO_TEST_USER"}, envErr.Missing)
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+
+	// The failure of the provider names its ID
+	c := NewContainer()
+	c.Fail("main.server", err)
+	assert.ErrorContains(t, c.Init(), "failed to create main.server: invalid environment of NewServer: missing required variables")
+}
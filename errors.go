@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrProviderNotFound is reported when no provider is registered with the requested ID.
+	// ErrProviderNotFound 表示没有注册指定ID的provider
+	ErrProviderNotFound = errors.New("provider not found")
+
+	// ErrGroupNotFound is reported when no member is registered into the requested group.
+	// ErrGroupNotFound 表示指定的组中没有注册任何成员
+	ErrGroupNotFound = errors.New("group not found")
+
+	// ErrTypeMismatch is reported when a registered object cannot be converted to the requested type.
+	// ErrTypeMismatch 表示注册的对象无法转换为请求的类型
+	ErrTypeMismatch = errors.New("type mismatch")
+
+	// ErrDuplicateProvider is reported when a provider is registered with an ID which is already registered.
+	// ErrDuplicateProvider 表示注册provider时使用的ID已经被注册过了
+	ErrDuplicateProvider = errors.New("duplicate provider")
+
+	// ErrDuplicateMember is reported when a member is registered with a name which is already used in the group.
+	// ErrDuplicateMember 表示注册组成员时使用的名字在组中已经被使用了
+	ErrDuplicateMember = errors.New("duplicate member")
+
+	// ErrValueNotFound is reported when a configuration value without a default is missing from the configuration source.
+	// ErrValueNotFound 表示配置源中缺少没有默认值的配置项
+	ErrValueNotFound = errors.New("value not found")
+
+	// ErrConfigNotLoaded is reported when a struct is bound to a configuration file before it is loaded by LoadConfig.
+	// ErrConfigNotLoaded 表示在通过LoadConfig加载配置文件之前绑定结构体
+	ErrConfigNotLoaded = errors.New("config not loaded")
+)
+
+// maxSuggestions is the maximum number of the near-miss IDs reported by a NotFoundError.
+const maxSuggestions = 3
+
+// NotFoundError is returned by Provide and Members when the requested ID is not registered.
+// It wraps ErrProviderNotFound or ErrGroupNotFound, and lists the registered IDs which are similar to the ID.
+// NotFoundError 表示请求的ID没有被注册，它包装了ErrProviderNotFound或者ErrGroupNotFound，
+// 并列出已注册的与该ID相似的ID
+type NotFoundError struct {
+	Id          string   // Id is the provider ID or the group ID that was requested.
+	Suggestions []string // Suggestions are the registered IDs similar to Id, the most similar first.
+	Err         error    // Err is ErrProviderNotFound or ErrGroupNotFound.
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	kind := "provider"
+	if e.Err == ErrGroupNotFound {
+		kind = "group"
+	}
+	msg := fmt.Sprintf("%s %s not found", kind, e.Id)
+	if len(e.Suggestions) > 0 {
+		msg += fmt.Sprintf(", did you mean %s?", strings.Join(e.Suggestions, ", "))
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error.
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// DuplicateError is returned by the registration functions when the ID is already registered into the container.
+// DuplicateError 表示注册的ID已经在容器中注册过了
+type DuplicateError struct {
+	Id       string // Id is the provider ID registered twice.
+	Package  string // Package is the package which tried to register the ID again.
+	Existing string // Existing is the package which registered the ID first.
+}
+
+// Error implements the error interface.
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("duplicate provider %s registered by %s, already registered by %s", e.Id, e.Package, e.Existing)
+}
+
+// Is reports whether the target is ErrDuplicateProvider, so that errors.Is(err, digo.ErrDuplicateProvider) works.
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicateProvider
+}
+
+// suggest returns the candidates which are similar to the id, ordered by their similarity.
+// A candidate is similar if the edit distance is small compared to the length of the id,
+// or if one of them contains the other.
+// suggest 返回与id相似的候选ID，按相似度排序。编辑距离相对于id的长度足够小，或者相互包含的候选ID被认为是相似的
+func suggest(id string, candidates []string) []string {
+	type suggestion struct {
+		id       string
+		distance int
+	}
+
+	threshold := len(id) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+
+	seen := make(map[string]bool)
+	suggestions := make([]suggestion, 0)
+	for _, candidate := range candidates {
+		if seen[candidate] || candidate == id {
+			continue
+		}
+		seen[candidate] = true
+		distance := levenshtein(id, candidate)
+		if distance <= threshold || strings.Contains(candidate, id) || strings.Contains(id, candidate) {
+			suggestions = append(suggestions, suggestion{id: candidate, distance: distance})
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].id < suggestions[j].id
+	})
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	ids := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		ids[i] = s.id
+	}
+	return ids
+}
+
+// levenshtein returns the edit distance between the strings a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// ProviderError reports that the constructor of a provider or a group member failed to create the object.
+// ProviderError 表示provider或者组成员的构造函数创建对象失败
+type ProviderError struct {
+	Id  string // Id is the ID of the provider, or the group and the constructor of a group member.
+	Err error  // Err is the error returned by the constructor, or the error of providing its dependencies.
+}
+
+// Error implements the error interface.
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("failed to create %s: %s", e.Id, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Path returns the dependency path of the failure, from the provider to the provider which caused the failure,
+// e.g. [main.app main.db] if main.app failed because its dependency main.db failed.
+// Path 返回失败的依赖路径，从当前provider一直到导致失败的provider
+func (e *ProviderError) Path() []string {
+	path := []string{e.Id}
+	var next *ProviderError
+	for err := e.Err; errors.As(err, &next); err = next.Err {
+		path = append(path, next.Id)
+	}
+	return path
+}
+
+// TypeMismatchError is returned by ProvideAs and MembersOf when a registered object
+// cannot be converted to the requested type.
+// TypeMismatchError 表示注册的对象无法转换为请求的类型
+type TypeMismatchError struct {
+	Id       string       // Id is the provider ID or the group ID that was requested.
+	Expected reflect.Type // Expected is the type requested by the caller.
+	Actual   reflect.Type // Actual is the dynamic type of the registered object, nil for a nil object.
+}
+
+// Is reports whether the target is ErrTypeMismatch, so that errors.Is(err, digo.ErrTypeMismatch) works.
+func (e *TypeMismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+// Error implements the error interface.
+func (e *TypeMismatchError) Error() string {
+	actual := "nil"
+	if e.Actual != nil {
+		actual = e.Actual.String()
+	}
+	return fmt.Sprintf("type mismatch for %s: expected %s, actual %s", e.Id, e.Expected, actual)
+}
+
+// ValueError is returned by ValueFrom when a configuration value is missing or cannot be converted
+// to the requested type.
+// ValueError 表示配置项不存在或者无法转换为请求的类型
+type ValueError struct {
+	Key   string       // Key is the configuration key that was requested.
+	Value string       // Value is the raw value of the configuration key, empty if the value is missing.
+	Type  reflect.Type // Type is the type requested by the caller, nil if the value is missing.
+	Err   error        // Err is the error of the conversion, or ErrValueNotFound.
+}
+
+// Error implements the error interface.
+func (e *ValueError) Error() string {
+	if e.Type == nil {
+		return fmt.Sprintf("config value %s: %v", e.Key, e.Err)
+	}
+	return fmt.Sprintf("failed to convert config value %s=%q to %s: %v", e.Key, e.Value, e.Type, e.Err)
+}
+
+// Unwrap returns the error of the conversion, so that errors.Is(err, digo.ErrValueNotFound) works.
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+// EnvError is reported when the environment variables injected into a constructor are missing or cannot be converted,
+// all the variables of the constructor are reported together.
+// EnvError 表示注入到构造函数中的环境变量缺失或者无法转换，构造函数的所有环境变量的错误会一起报告
+type EnvError struct {
+	Function string   // Function is the name of the constructor which the variables are injected into.
+	Missing  []string // Missing are the names of the required variables which are not set.
+	Errs     []error  // Errs are the errors of the variables which cannot be converted.
+}
+
+// Error implements the error interface.
+func (e *EnvError) Error() string {
+	msgs := make([]string, 0, len(e.Errs)+1)
+	if len(e.Missing) > 0 {
+		msgs = append(msgs, "missing required variables "+strings.Join(e.Missing, ", "))
+	}
+	for _, err := range e.Errs {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("invalid environment of %s: %s", e.Function, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the variables which cannot be converted.
+func (e *EnvError) Unwrap() []error {
+	return e.Errs
+}
+
+// ConfigError is returned by LoadConfig and BindConfig when a configuration file cannot be loaded,
+// or a section of it cannot be bound to a struct.
+// ConfigError 表示配置文件无法加载，或者其中的配置段无法绑定到结构体
+type ConfigError struct {
+	Path string // Path is the path of the configuration file.
+	Key  string // Key is the dotted key of the value which cannot be bound, empty if the file cannot be loaded.
+	Err  error  // Err is the error of the loading or the binding.
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string {
+	if len(e.Key) == 0 {
+		return fmt.Sprintf("config file %s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("config file %s: %s: %v", e.Path, e.Key, e.Err)
+}
+
+// Unwrap returns the error of the loading or the binding.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
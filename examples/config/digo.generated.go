@@ -0,0 +1,97 @@
+
+//
+// This file is generated by digogen. Run 'digogen' to regenerate.
+//
+// You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
+package main
+
+import "github.com/werbenhu/digo"
+
+// init_config_server registers the lazy singleton object with ID config.server into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("config.server")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*ServerConfig](c, "config.server")` to retrieve it as its actual type.
+// The typed getter ProvideConfigServer() retrieves it from the default container as well.
+func init_config_server(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "config.server", Type: "*ServerConfig", Package: "github.com/werbenhu/digo/examples/config", Scope: "singleton", Lazy: true})
+	c.RegisterLazy("config.server", func(c *digo.Container) (any, error) {
+		return digo.BindConfig(c, "server", &ServerConfig{})
+	})
+}
+
+// init_main_dbconfig registers the lazy singleton object with ID main.dbconfig into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.dbconfig")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*DbConfig](c, "main.dbconfig")` to retrieve it as its actual type.
+// The typed getter ProvideMainDbconfig() retrieves it from the default container as well.
+func init_main_dbconfig(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.dbconfig", Type: "*DbConfig", Constructor: "NewDbConfig", Package: "github.com/werbenhu/digo/examples/config", Scope: "singleton", Lazy: true})
+	c.RegisterLazy("main.dbconfig", func(c *digo.Container) (any, error) {
+		return digo.BindConfig(c, "database", NewDbConfig())
+	})
+}
+
+// init_main_app registers the lazy singleton object with ID main.app into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.app")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*App](c, "main.app")` to retrieve it as its actual type.
+// The typed getter ProvideMainApp() retrieves it from the default container as well.
+func init_main_app(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.app", Type: "*App", Constructor: "NewApp", Package: "github.com/werbenhu/digo/examples/config", Scope: "singleton", Lazy: true, Dependencies: []string{"config.server", "main.dbconfig"}})
+	c.RegisterLazy("main.app", func(c *digo.Container) (any, error) {
+		server_dep, err := digo.ProvideFrom[*ServerConfig](c, "config.server")
+		if err != nil {
+			return nil, err
+		}
+		db_dep, err := digo.ProvideFrom[*DbConfig](c, "main.dbconfig")
+		if err != nil {
+			return nil, err
+		}
+		return NewApp(server_dep, db_dep), nil
+	})
+}
+
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	init_config_server(c)
+	init_main_dbconfig(c)
+	init_main_app(c)
+}
+
+// init registers all providers in the current package into the default container.
+func init() {
+	Register(digo.Default())
+}
+
+// ProvideConfigServer returns the singleton object with ID config.server.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*ServerConfig]("config.server")` to handle the error instead.
+func ProvideConfigServer() *ServerConfig {
+	obj, err := digo.ProvideAs[*ServerConfig]("config.server")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideMainDbconfig returns the singleton object with ID main.dbconfig.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*DbConfig]("main.dbconfig")` to handle the error instead.
+func ProvideMainDbconfig() *DbConfig {
+	obj, err := digo.ProvideAs[*DbConfig]("main.dbconfig")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideMainApp returns the singleton object with ID main.app.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*App]("main.app")` to handle the error instead.
+func ProvideMainApp() *App {
+	obj, err := digo.ProvideAs[*App]("main.app")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
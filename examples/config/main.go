@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/werbenhu/digo"
+)
+
+// ServerConfig is populated with the section "server" of the configuration file.
+// @config({"prefix":"server"})
+type ServerConfig struct {
+	Port    int           `config:"port,required"`
+	Host    string        `config:"host" default:"0.0.0.0"`
+	Timeout time.Duration `config:"timeout"`
+}
+
+type DbConfig struct {
+	Url  string
+	Pool struct {
+		Size int `config:"size"`
+	}
+	Debug bool
+}
+
+// The object returned by the provider keeps its values for the keys missing in the configuration file.
+// @provider({"id":"main.dbconfig"})
+// @config({"prefix":"database"})
+func NewDbConfig() *DbConfig {
+	return &DbConfig{Debug: true}
+}
+
+type App struct {
+	server *ServerConfig
+	db     *DbConfig
+}
+
+// @provider({"id":"main.app"})
+// @inject({"param":"server", "id":"config.server"})
+// @inject({"param":"db", "id":"main.dbconfig"})
+func NewApp(server *ServerConfig, db *DbConfig) *App {
+	return &App{
+		server: server,
+		db:     db,
+	}
+}
+
+func (a *App) Start() {
+	log.Printf("app start, listen:%s:%d, timeout:%s, db:%s, pool:%d, debug:%t\n",
+		a.server.Host, a.server.Port, a.server.Timeout, a.db.Url, a.db.Pool.Size, a.db.Debug)
+}
+
+func main() {
+	if err := digo.Init(); err != nil {
+		log.Fatal(err)
+	}
+	defer digo.Close(context.Background())
+
+	// The providers bound to the configuration file are created after it is loaded.
+	if err := digo.LoadConfig("config.yaml"); err != nil {
+		log.Fatal(err)
+	}
+
+	app := ProvideMainApp()
+	app.Start()
+}
@@ -1,61 +1,132 @@
+
+//
 // This file is generated by digogen. Run 'digogen' to regenerate.
 //
 // You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
-// For more details, please refer to https://github.com/werbenhu/digo.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
 package main
 
 import "github.com/werbenhu/digo"
 
-// init_main_user_name registers the singleton object with ID main.user.name into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("main.user.name")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_main_user_name() {
+// init_main_user_name registers the singleton object with ID main.user.name into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.user.name")`.
+// The obj is of type `any`, use `digo.ProvideFrom[string](c, "main.user.name")` to retrieve it as its actual type.
+// The typed getter ProvideMainUserName() retrieves it from the default container as well.
+func init_main_user_name(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.user.name", Type: "string", Constructor: "NewUserName", Package: "github.com/werbenhu/digo/examples/group", Scope: "singleton"})
 	main_user_name_obj := NewUserName()
-	digo.RegisterSingleton("main.user.name", main_user_name_obj)
+	c.RegisterSingleton("main.user.name", main_user_name_obj)
 }
 
-// init_main_role_name registers the singleton object with ID main.role.name into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("main.role.name")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_main_role_name() {
+// init_main_role_name registers the singleton object with ID main.role.name into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.role.name")`.
+// The obj is of type `any`, use `digo.ProvideFrom[string](c, "main.role.name")` to retrieve it as its actual type.
+// The typed getter ProvideMainRoleName() retrieves it from the default container as well.
+func init_main_role_name(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.role.name", Type: "string", Constructor: "NewRoleName", Package: "github.com/werbenhu/digo/examples/group", Scope: "singleton"})
 	main_role_name_obj := NewRoleName()
-	digo.RegisterSingleton("main.role.name", main_role_name_obj)
+	c.RegisterSingleton("main.role.name", main_role_name_obj)
+}
+
+// init_main_router registers the singleton object with ID main.router into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.router")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Router](c, "main.router")` to retrieve it as its actual type.
+// The typed getter ProvideMainRouter() retrieves it from the default container as well.
+func init_main_router(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.router", Type: "*Router", Constructor: "NewRouter", Package: "github.com/werbenhu/digo/examples/group", Scope: "singleton", GroupDependencies: []string{"controllers"}})
+	ctrls_dep, err := digo.MembersFrom[Controller](c, "controllers")
+	if err != nil {
+		c.Fail("main.router", err)
+		return
+	}
+	main_router_obj := NewRouter(ctrls_dep)
+	c.RegisterSingleton("main.router", main_router_obj)
 }
 
-// Add a member object to group: controllers
-// Now you can retrieve the group's member objects by using `objs, err := digo.Members("controllers")`.
-// The objs obtained from the above code are of type `[]any`.
-// You will need to forcefully cast the objs to their corresponding actual object types.
-func group_controllers_NewUserController() {
-	name_obj, err := digo.Provide("main.user.name")
+// Add a member object to group controllers of the container c
+// Now you can retrieve the group's member objects by using `objs, err := c.Members("controllers")`.
+// The member object is named user, you can also retrieve it by using `objs, err := c.MemberMap("controllers")`.
+// The objs are of type `[]any`, use `digo.MembersFrom[Controller](c, "controllers")` to retrieve them as a typed slice.
+// The typed getter MembersControllers() retrieves them from the default container as well.
+func group_controllers_NewUserController(c *digo.Container) {
+	c.Describe(digo.Metadata{Type: "*UserController", Constructor: "NewUserController", Package: "github.com/werbenhu/digo/examples/group", Dependencies: []string{"main.user.name"}, Groups: []string{"controllers"}, Name: "user"})
+	name_dep, err := digo.ProvideFrom[string](c, "main.user.name")
+	if err != nil {
+		c.FailMember("controllers", "NewUserController", err)
+		return
+	}
+	member := NewUserController(name_dep)
+	c.RegisterMemberWith("controllers", member, digo.MemberOptions{Name: "user", Source: "github.com/werbenhu/digo/examples/group/main.go", Line: 24})
+}
+
+// Add a member object to group controllers of the container c
+// Now you can retrieve the group's member objects by using `objs, err := c.Members("controllers")`.
+// The member object is named role, you can also retrieve it by using `objs, err := c.MemberMap("controllers")`.
+// The objs are of type `[]any`, use `digo.MembersFrom[Controller](c, "controllers")` to retrieve them as a typed slice.
+// The typed getter MembersControllers() retrieves them from the default container as well.
+func group_controllers_NewRoleController(c *digo.Container) {
+	c.Describe(digo.Metadata{Type: "*RoleController", Constructor: "NewRoleController", Package: "github.com/werbenhu/digo/examples/group", Dependencies: []string{"main.role.name"}, Groups: []string{"controllers"}, Name: "role"})
+	name_dep, err := digo.ProvideFrom[string](c, "main.role.name")
+	if err != nil {
+		c.FailMember("controllers", "NewRoleController", err)
+		return
+	}
+	member := NewRoleController(name_dep)
+	c.RegisterMemberWith("controllers", member, digo.MemberOptions{Name: "role", Source: "github.com/werbenhu/digo/examples/group/main.go", Line: 45})
+}
+
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	init_main_user_name(c)
+	init_main_role_name(c)
+	group_controllers_NewUserController(c)
+	group_controllers_NewRoleController(c)
+	init_main_router(c)
+}
+
+// init registers all providers in the current package into the default container.
+func init() {
+	Register(digo.Default())
+}
+
+// ProvideMainUserName returns the singleton object with ID main.user.name.
+// It panics if the object cannot be provided, use `digo.ProvideAs[string]("main.user.name")` to handle the error instead.
+func ProvideMainUserName() string {
+	obj, err := digo.ProvideAs[string]("main.user.name")
 	if err != nil {
 		panic(err)
 	}
-	name := name_obj.(string)
-	member := NewUserController(name)
-	digo.RegisterMember("controllers", member)
+	return obj
 }
 
-// Add a member object to group: controllers
-// Now you can retrieve the group's member objects by using `objs, err := digo.Members("controllers")`.
-// The objs obtained from the above code are of type `[]any`.
-// You will need to forcefully cast the objs to their corresponding actual object types.
-func group_controllers_NewRoleController() {
-	name_obj, err := digo.Provide("main.role.name")
+// ProvideMainRoleName returns the singleton object with ID main.role.name.
+// It panics if the object cannot be provided, use `digo.ProvideAs[string]("main.role.name")` to handle the error instead.
+func ProvideMainRoleName() string {
+	obj, err := digo.ProvideAs[string]("main.role.name")
 	if err != nil {
 		panic(err)
 	}
-	name := name_obj.(string)
-	member := NewRoleController(name)
-	digo.RegisterMember("controllers", member)
+	return obj
 }
 
-// init registers all providers in the current package into the DI object manager.
-func init() {
-	init_main_user_name()
-	init_main_role_name()
-	group_controllers_NewUserController()
-	group_controllers_NewRoleController()
+// ProvideMainRouter returns the singleton object with ID main.router.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Router]("main.router")` to handle the error instead.
+func ProvideMainRouter() *Router {
+	obj, err := digo.ProvideAs[*Router]("main.router")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// MembersControllers returns the member objects of group controllers.
+// It panics if the members cannot be provided, use `digo.MembersOf[Controller]("controllers")` to handle the error instead.
+func MembersControllers() []Controller {
+	obj, err := digo.MembersOf[Controller]("controllers")
+	if err != nil {
+		panic(err)
+	}
+	return obj
 }
@@ -19,7 +19,7 @@ type UserController struct {
 	Name string
 }
 
-// @group({"id":"controllers"})
+// @group({"id":"controllers", "type":"Controller", "name":"user"})
 // @inject({"param":"name", "id":"main.user.name"})
 func NewUserController(name string) *UserController {
 	return &UserController{
@@ -40,7 +40,7 @@ type RoleController struct {
 	Name string
 }
 
-// @group({"id":"controllers"})
+// @group({"id":"controllers", "type":"Controller", "name":"role"})
 // @inject({"param":"name", "id":"main.role.name"})
 func NewRoleController(name string) *RoleController {
 	return &RoleController{
@@ -52,12 +52,29 @@ func (c *RoleController) Print() {
 	fmt.Printf("role controller name:%s\n", c.Name)
 }
 
+type Router struct {
+	Controllers []Controller
+}
+
+// @provider({"id":"main.router"})
+// @inject({"param":"ctrls", "group":"controllers"})
+func NewRouter(ctrls []Controller) *Router {
+	return &Router{
+		Controllers: ctrls,
+	}
+}
+
 func main() {
-	members, err := digo.Members("controllers")
+	for _, member := range MembersControllers() {
+		member.Print()
+	}
+
+	// The whole group is injected into the router
+	fmt.Printf("router controllers:%d\n", len(ProvideMainRouter().Controllers))
 
+	// Look up a member by its name
+	members, err := digo.MemberMap("controllers")
 	if err == nil {
-		for _, member := range members {
-			member.(Controller).Print()
-		}
+		members["user"].(Controller).Print()
 	}
 }
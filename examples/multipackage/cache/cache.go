@@ -0,0 +1,18 @@
+package cache
+
+type Cache interface {
+	Name() string
+}
+
+type MemoryCache struct {
+}
+
+func (m *MemoryCache) Name() string {
+	return "memory"
+}
+
+// The in-memory cache is a fallback, which is registered only if no other package provides "cache".
+// @provider({"id": "cache", "onMissing": true})
+func NewMemoryCache() Cache {
+	return &MemoryCache{}
+}
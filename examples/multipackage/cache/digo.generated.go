@@ -0,0 +1,20 @@
+
+//
+// This file is generated by digogen. Run 'digogen' to regenerate.
+//
+// You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
+package cache
+
+import "github.com/werbenhu/digo"
+
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+}
+
+// init registers all providers in the current package into the default container.
+func init() {
+	Register(digo.Default())
+}
@@ -1,61 +1,104 @@
+
+//
 // This file is generated by digogen. Run 'digogen' to regenerate.
 //
 // You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
-// For more details, please refer to https://github.com/werbenhu/digo.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
 package controllers
 
 import "github.com/werbenhu/digo"
 
-// init_main_role_name registers the singleton object with ID main.role.name into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("main.role.name")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_main_role_name() {
+// init_main_role_name registers the singleton object with ID main.role.name into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.role.name")`.
+// The obj is of type `any`, use `digo.ProvideFrom[string](c, "main.role.name")` to retrieve it as its actual type.
+// The typed getter ProvideMainRoleName() retrieves it from the default container as well.
+func init_main_role_name(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.role.name", Type: "string", Constructor: "NewRoleName", Package: "github.com/werbenhu/digo/examples/multipackage/controllers", Scope: "singleton"})
 	main_role_name_obj := NewRoleName()
-	digo.RegisterSingleton("main.role.name", main_role_name_obj)
+	c.RegisterSingleton("main.role.name", main_role_name_obj)
 }
 
-// init_main_user_name registers the singleton object with ID main.user.name into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("main.user.name")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_main_user_name() {
+// init_main_user_name registers the singleton object with ID main.user.name into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.user.name")`.
+// The obj is of type `any`, use `digo.ProvideFrom[string](c, "main.user.name")` to retrieve it as its actual type.
+// The typed getter ProvideMainUserName() retrieves it from the default container as well.
+func init_main_user_name(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.user.name", Type: "string", Constructor: "NewUserName", Package: "github.com/werbenhu/digo/examples/multipackage/controllers", Scope: "singleton"})
 	main_user_name_obj := NewUserName()
-	digo.RegisterSingleton("main.user.name", main_user_name_obj)
+	c.RegisterSingleton("main.user.name", main_user_name_obj)
+}
+
+// Add a member object to group group.controllers of the container c
+// Now you can retrieve the group's member objects by using `objs, err := c.Members("group.controllers")`.
+// The objs are of type `[]any`, use `digo.MembersFrom[Controller](c, "group.controllers")` to retrieve them as a typed slice.
+// The typed getter MembersGroupControllers() retrieves them from the default container as well.
+func group_group_controllers_NewRoleController(c *digo.Container) {
+	c.Describe(digo.Metadata{Type: "*RoleController", Constructor: "NewRoleController", Package: "github.com/werbenhu/digo/examples/multipackage/controllers", Dependencies: []string{"main.role.name"}, Groups: []string{"group.controllers"}})
+	name_dep, err := digo.ProvideFrom[string](c, "main.role.name")
+	if err != nil {
+		c.FailMember("group.controllers", "NewRoleController", err)
+		return
+	}
+	member := NewRoleController(name_dep)
+	c.RegisterMemberWith("group.controllers", member, digo.MemberOptions{Source: "github.com/werbenhu/digo/examples/multipackage/controllers/role.go", Line: 16})
+}
+
+// Add a member object to group group.controllers of the container c
+// Now you can retrieve the group's member objects by using `objs, err := c.Members("group.controllers")`.
+// The objs are of type `[]any`, use `digo.MembersFrom[Controller](c, "group.controllers")` to retrieve them as a typed slice.
+// The typed getter MembersGroupControllers() retrieves them from the default container as well.
+func group_group_controllers_NewUserController(c *digo.Container) {
+	c.Describe(digo.Metadata{Type: "*UserController", Constructor: "NewUserController", Package: "github.com/werbenhu/digo/examples/multipackage/controllers", Dependencies: []string{"main.user.name"}, Groups: []string{"group.controllers"}})
+	name_dep, err := digo.ProvideFrom[string](c, "main.user.name")
+	if err != nil {
+		c.FailMember("group.controllers", "NewUserController", err)
+		return
+	}
+	member := NewUserController(name_dep)
+	c.RegisterMemberWith("group.controllers", member, digo.MemberOptions{Source: "github.com/werbenhu/digo/examples/multipackage/controllers/user.go", Line: 16})
+}
+
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	init_main_role_name(c)
+	init_main_user_name(c)
+	group_group_controllers_NewRoleController(c)
+	group_group_controllers_NewUserController(c)
+}
+
+// init registers all providers in the current package into the default container.
+func init() {
+	Register(digo.Default())
 }
 
-// Add a member object to group: group.controllers
-// Now you can retrieve the group's member objects by using `objs, err := digo.Members("group.controllers")`.
-// The objs obtained from the above code are of type `[]any`.
-// You will need to forcefully cast the objs to their corresponding actual object types.
-func group_group_controllers_NewRoleController() {
-	name_obj, err := digo.Provide("main.role.name")
+// ProvideMainRoleName returns the singleton object with ID main.role.name.
+// It panics if the object cannot be provided, use `digo.ProvideAs[string]("main.role.name")` to handle the error instead.
+func ProvideMainRoleName() string {
+	obj, err := digo.ProvideAs[string]("main.role.name")
 	if err != nil {
 		panic(err)
 	}
-	name := name_obj.(string)
-	member := NewRoleController(name)
-	digo.RegisterMember("group.controllers", member)
+	return obj
 }
 
-// Add a member object to group: group.controllers
-// Now you can retrieve the group's member objects by using `objs, err := digo.Members("group.controllers")`.
-// The objs obtained from the above code are of type `[]any`.
-// You will need to forcefully cast the objs to their corresponding actual object types.
-func group_group_controllers_NewUserController() {
-	name_obj, err := digo.Provide("main.user.name")
+// ProvideMainUserName returns the singleton object with ID main.user.name.
+// It panics if the object cannot be provided, use `digo.ProvideAs[string]("main.user.name")` to handle the error instead.
+func ProvideMainUserName() string {
+	obj, err := digo.ProvideAs[string]("main.user.name")
 	if err != nil {
 		panic(err)
 	}
-	name := name_obj.(string)
-	member := NewUserController(name)
-	digo.RegisterMember("group.controllers", member)
+	return obj
 }
 
-// init registers all providers in the current package into the DI object manager.
-func init() {
-	init_main_role_name()
-	init_main_user_name()
-	group_group_controllers_NewRoleController()
-	group_group_controllers_NewUserController()
+// MembersGroupControllers returns the member objects of group group.controllers.
+// It panics if the members cannot be provided, use `digo.MembersOf[Controller]("group.controllers")` to handle the error instead.
+func MembersGroupControllers() []Controller {
+	obj, err := digo.MembersOf[Controller]("group.controllers")
+	if err != nil {
+		panic(err)
+	}
+	return obj
 }
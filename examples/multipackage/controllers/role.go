@@ -11,7 +11,7 @@ type RoleController struct {
 	Name string
 }
 
-// @group({"id":"group.controllers"})
+// @group({"id":"group.controllers", "type":"Controller"})
 // @inject({"param":"name", "id":"main.role.name"})
 func NewRoleController(name string) *RoleController {
 	return &RoleController{
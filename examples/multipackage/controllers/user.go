@@ -11,7 +11,7 @@ type UserController struct {
 	Name string
 }
 
-// @group({"id":"group.controllers"})
+// @group({"id":"group.controllers", "type":"Controller"})
 // @inject({"param":"name", "id":"main.user.name"})
 func NewUserController(name string) *UserController {
 	return &UserController{
@@ -1,36 +1,96 @@
+
+//
 // This file is generated by digogen. Run 'digogen' to regenerate.
 //
 // You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
-// For more details, please refer to https://github.com/werbenhu/digo.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
 package database
 
-import "github.com/werbenhu/digo"
+import (
+	"github.com/werbenhu/digo/examples/multipackage/cache"
+	"github.com/werbenhu/digo"
+)
 
-// init_database_mysql_url registers the singleton object with ID database.mysql.url into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("database.mysql.url")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_database_mysql_url() {
+// init_database_mysql_url registers the singleton object with ID database.mysql.url into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("database.mysql.url")`.
+// The obj is of type `any`, use `digo.ProvideFrom[string](c, "database.mysql.url")` to retrieve it as its actual type.
+// The typed getter ProvideDatabaseMysqlUrl() retrieves it from the default container as well.
+func init_database_mysql_url(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "database.mysql.url", Type: "string", Constructor: "NewMysqlUrl", Package: "github.com/werbenhu/digo/examples/multipackage/database", Scope: "singleton"})
 	database_mysql_url_obj := NewMysqlUrl()
-	digo.RegisterSingleton("database.mysql.url", database_mysql_url_obj)
+	c.RegisterSingleton("database.mysql.url", database_mysql_url_obj)
 }
 
-// init_database_mysql registers the singleton object with ID database.mysql into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("database.mysql")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_database_mysql() {
-	url_obj, err := digo.Provide("database.mysql.url")
+// init_database_mysql registers the singleton object with ID database.mysql into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("database.mysql")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Mysql](c, "database.mysql")` to retrieve it as its actual type.
+// The typed getter ProvideDatabaseMysql() retrieves it from the default container as well.
+func init_database_mysql(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "database.mysql", Type: "*Mysql", Constructor: "NewMysql", Package: "github.com/werbenhu/digo/examples/multipackage/database", Scope: "singleton", Dependencies: []string{"database.mysql.url"}})
+	url_dep, err := digo.ProvideFrom[string](c, "database.mysql.url")
 	if err != nil {
-		panic(err)
+		c.Fail("database.mysql", err)
+		return
 	}
-	url := url_obj.(string)
-	database_mysql_obj := NewMysql(url)
-	digo.RegisterSingleton("database.mysql", database_mysql_obj)
+	database_mysql_obj := NewMysql(url_dep)
+	c.RegisterSingleton("database.mysql", database_mysql_obj)
 }
 
-// init registers all providers in the current package into the DI object manager.
+// init_cache registers the singleton object with ID cache into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("cache")`.
+// The obj is of type `any`, use `digo.ProvideFrom[cache.Cache](c, "cache")` to retrieve it as its actual type.
+// The typed getter ProvideCache() retrieves it from the default container as well.
+func init_cache(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "cache", Type: "cache.Cache", Constructor: "NewMysqlCache", Package: "github.com/werbenhu/digo/examples/multipackage/database", Scope: "singleton", Dependencies: []string{"database.mysql"}})
+	mysql_dep, err := digo.ProvideFrom[*Mysql](c, "database.mysql")
+	if err != nil {
+		c.Fail("cache", err)
+		return
+	}
+	cache_obj := NewMysqlCache(mysql_dep)
+	c.RegisterSingleton("cache", cache_obj)
+}
+
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	init_database_mysql_url(c)
+	init_database_mysql(c)
+	init_cache(c)
+}
+
+// init registers all providers in the current package into the default container.
 func init() {
-	init_database_mysql_url()
-	init_database_mysql()
+	Register(digo.Default())
+}
+
+// ProvideDatabaseMysqlUrl returns the singleton object with ID database.mysql.url.
+// It panics if the object cannot be provided, use `digo.ProvideAs[string]("database.mysql.url")` to handle the error instead.
+func ProvideDatabaseMysqlUrl() string {
+	obj, err := digo.ProvideAs[string]("database.mysql.url")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideDatabaseMysql returns the singleton object with ID database.mysql.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Mysql]("database.mysql")` to handle the error instead.
+func ProvideDatabaseMysql() *Mysql {
+	obj, err := digo.ProvideAs[*Mysql]("database.mysql")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideCache returns the singleton object with ID cache.
+// It panics if the object cannot be provided, use `digo.ProvideAs[cache.Cache]("cache")` to handle the error instead.
+func ProvideCache() cache.Cache {
+	obj, err := digo.ProvideAs[cache.Cache]("cache")
+	if err != nil {
+		panic(err)
+	}
+	return obj
 }
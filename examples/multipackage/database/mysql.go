@@ -1,6 +1,10 @@
 package database
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/werbenhu/digo/examples/multipackage/cache"
+)
 
 // @provider({"id": "database.mysql.url"})
 func NewMysqlUrl() string {
@@ -26,3 +30,21 @@ func NewMysql(url string) *Mysql {
 func (m *Mysql) Print() {
 	fmt.Printf("Mysql Print url:%s\n", m.Url)
 }
+
+type MysqlCache struct {
+	mysql *Mysql
+}
+
+func (c *MysqlCache) Name() string {
+	return "mysql:" + c.mysql.Url
+}
+
+// The cache backed by mysql is registered only if "database.mysql" is provided,
+// in which case the fallback in-memory cache is not registered.
+// @provider({"id": "cache", "conditionalOn": "database.mysql"})
+// @inject({"param":"mysql", "id":"database.mysql"})
+func NewMysqlCache(mysql *Mysql) cache.Cache {
+	return &MysqlCache{
+		mysql: mysql,
+	}
+}
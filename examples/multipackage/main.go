@@ -1,22 +1,29 @@
 package main
 
 import (
+	"log"
+
 	"github.com/werbenhu/digo"
+	"github.com/werbenhu/digo/examples/multipackage/cache"
 	"github.com/werbenhu/digo/examples/multipackage/controllers"
 	"github.com/werbenhu/digo/examples/multipackage/models"
 )
 
 func main() {
-	user, err := digo.Provide("model.user")
-	if err == nil {
-		user.(*models.User).Print()
+	if err := digo.Init(); err != nil {
+		log.Fatal(err)
 	}
 
-	members, err := digo.Members("group.controllers")
+	user := models.ProvideModelUser()
+	user.Print()
+
+	c, err := digo.ProvideAs[cache.Cache]("cache")
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("cache:%s\n", c.Name())
 
-	if err == nil {
-		for _, member := range members {
-			member.(controllers.Controller).Print()
-		}
+	for _, member := range controllers.MembersGroupControllers() {
+		member.Print()
 	}
 }
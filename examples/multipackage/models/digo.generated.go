@@ -1,29 +1,49 @@
+
+//
 // This file is generated by digogen. Run 'digogen' to regenerate.
 //
 // You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
-// For more details, please refer to https://github.com/werbenhu/digo.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
 package models
 
 import (
-	"github.com/werbenhu/digo"
 	"github.com/werbenhu/digo/examples/multipackage/database"
+	"github.com/werbenhu/digo"
 )
 
-// init_model_user registers the singleton object with ID model.user into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("model.user")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_model_user() {
-	db_obj, err := digo.Provide("database.mysql")
+// init_model_user registers the singleton object with ID model.user into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("model.user")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*User](c, "model.user")` to retrieve it as its actual type.
+// The typed getter ProvideModelUser() retrieves it from the default container as well.
+func init_model_user(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "model.user", Type: "*User", Constructor: "NewUser", Package: "github.com/werbenhu/digo/examples/multipackage/models", Scope: "singleton", Dependencies: []string{"database.mysql"}})
+	db_dep, err := digo.ProvideFrom[database.Database](c, "database.mysql")
 	if err != nil {
-		panic(err)
+		c.Fail("model.user", err)
+		return
 	}
-	db := db_obj.(database.Database)
-	model_user_obj := NewUser(db)
-	digo.RegisterSingleton("model.user", model_user_obj)
+	model_user_obj := NewUser(db_dep)
+	c.RegisterSingleton("model.user", model_user_obj)
 }
 
-// init registers all providers in the current package into the DI object manager.
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	init_model_user(c)
+}
+
+// init registers all providers in the current package into the default container.
 func init() {
-	init_model_user()
+	Register(digo.Default())
+}
+
+// ProvideModelUser returns the singleton object with ID model.user.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*User]("model.user")` to handle the error instead.
+func ProvideModelUser() *User {
+	obj, err := digo.ProvideAs[*User]("model.user")
+	if err != nil {
+		panic(err)
+	}
+	return obj
 }
@@ -0,0 +1,89 @@
+
+//
+// This file is generated by digogen. Run 'digogen' to regenerate.
+//
+// You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
+package main
+
+import "github.com/werbenhu/digo"
+
+// init_main_mailer_prod registers the lazy singleton object with ID main.mailer into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.mailer")`.
+// The obj is of type `any`, use `digo.ProvideFrom[Mailer](c, "main.mailer")` to retrieve it as its actual type.
+// The typed getter ProvideMainMailer() retrieves it from the default container as well.
+func init_main_mailer_prod(c *digo.Container) {
+	c.RegisterLazy("main.mailer", func(c *digo.Container) (any, error) {
+		env_vars := digo.NewEnvironment("NewSmtpMailer")
+		addr_dep := digo.LookupEnv[string](env_vars, "SMTP_ADDR", false, "smtp.example.com:25")
+		err := env_vars.Err()
+		if err != nil {
+			return nil, err
+		}
+		return NewSmtpMailer(addr_dep), nil
+	})
+}
+
+// init_main_mailer_dev registers the lazy singleton object with ID main.mailer into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.mailer")`.
+// The obj is of type `any`, use `digo.ProvideFrom[Mailer](c, "main.mailer")` to retrieve it as its actual type.
+// The typed getter ProvideMainMailer() retrieves it from the default container as well.
+func init_main_mailer_dev(c *digo.Container) {
+	c.RegisterLazy("main.mailer", func(c *digo.Container) (any, error) {
+		return NewFakeMailer(), nil
+	})
+}
+
+// init_main_app registers the lazy singleton object with ID main.app into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.app")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*App](c, "main.app")` to retrieve it as its actual type.
+// The typed getter ProvideMainApp() retrieves it from the default container as well.
+func init_main_app(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.app", Type: "*App", Constructor: "NewApp", Package: "github.com/werbenhu/digo/examples/profile", Scope: "singleton", Lazy: true, Dependencies: []string{"main.mailer"}})
+	c.RegisterLazy("main.app", func(c *digo.Container) (any, error) {
+		mailer_dep, err := digo.ProvideFrom[Mailer](c, "main.mailer")
+		if err != nil {
+			return nil, err
+		}
+		return NewApp(mailer_dep), nil
+	})
+}
+
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.mailer", Type: "Mailer", Constructor: "NewSmtpMailer", Package: "github.com/werbenhu/digo/examples/profile", Scope: "singleton", Lazy: true, Profile: "prod"})
+	c.RegisterProfile("prod", init_main_mailer_prod)
+	c.Describe(digo.Metadata{Id: "main.mailer", Type: "Mailer", Constructor: "NewFakeMailer", Package: "github.com/werbenhu/digo/examples/profile", Scope: "singleton", Lazy: true, Profile: "dev"})
+	c.RegisterProfile("dev", init_main_mailer_dev)
+	init_main_app(c)
+}
+
+// init registers all providers in the current package into the default container.
+func init() {
+	Register(digo.Default())
+}
+
+// ProvideMainMailer returns the singleton object with ID main.mailer.
+// It panics if the object cannot be provided, use `digo.ProvideAs[Mailer]("main.mailer")` to handle the error instead.
+func ProvideMainMailer() Mailer {
+	obj, err := digo.ProvideAs[Mailer]("main.mailer")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideMainApp returns the singleton object with ID main.app.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*App]("main.app")` to handle the error instead.
+func ProvideMainApp() *App {
+	obj, err := digo.ProvideAs[*App]("main.app")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/werbenhu/digo"
+)
+
+type Mailer interface {
+	Send(to string, body string) error
+}
+
+type SmtpMailer struct {
+	addr string
+}
+
+func (m *SmtpMailer) Send(to string, body string) error {
+	log.Printf("smtp %s send to:%s, body:%s\n", m.addr, to, body)
+	return nil
+}
+
+// @provider({"id":"main.mailer", "profile":"prod"})
+// @env({"param":"addr", "name":"SMTP_ADDR", "default":"smtp.example.com:25"})
+func NewSmtpMailer(addr string) Mailer {
+	return &SmtpMailer{addr: addr}
+}
+
+type FakeMailer struct {
+}
+
+func (m *FakeMailer) Send(to string, body string) error {
+	log.Printf("fake send to:%s, body:%s\n", to, body)
+	return nil
+}
+
+// @provider({"id":"main.mailer", "profile":"dev"})
+func NewFakeMailer() Mailer {
+	return &FakeMailer{}
+}
+
+type App struct {
+	mailer Mailer
+}
+
+// @provider({"id":"main.app"})
+// @inject({"param":"mailer", "id":"main.mailer"})
+func NewApp(mailer Mailer) *App {
+	return &App{mailer: mailer}
+}
+
+func (a *App) Start() {
+	a.mailer.Send("user@example.com", "app start")
+}
+
+func main() {
+	if err := digo.Init(); err != nil {
+		log.Fatal(err)
+	}
+	defer digo.Close(context.Background())
+
+	// The profile is selected by DIGO_PROFILE, e.g. DIGO_PROFILE=prod go run ., and is "dev" by default.
+	if len(digo.Profile()) == 0 {
+		if err := digo.SetProfile("dev"); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	app := ProvideMainApp()
+	app.Start()
+}
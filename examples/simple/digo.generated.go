@@ -1,66 +1,111 @@
+
+//
 // This file is generated by digogen. Run 'digogen' to regenerate.
 //
 // You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
-// For more details, please refer to https://github.com/werbenhu/digo.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
 package main
 
 import "github.com/werbenhu/digo"
 
-// init_main_db_url registers the singleton object with ID main.db.url into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("main.db.url")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_main_db_url() {
-	main_db_url_obj := NewDbUrl()
-	digo.RegisterSingleton("main.db.url", main_db_url_obj)
+// init_main_db registers the lazy singleton object with ID main.db into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.db")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Db](c, "main.db")` to retrieve it as its actual type.
+// The typed getter ProvideMainDb() retrieves it from the default container as well.
+func init_main_db(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.db", Type: "*Db", Constructor: "NewDb", Package: "github.com/werbenhu/digo/examples/simple", Scope: "singleton", Lazy: true})
+	c.RegisterLazy("main.db", func(c *digo.Container) (any, error) {
+		url_dep, err := digo.ValueFrom[string](c, "db.url", "localhost:3306")
+		if err != nil {
+			return nil, err
+		}
+		return NewDb(url_dep)
+	})
 }
 
-// init_main_db registers the singleton object with ID main.db into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("main.db")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_main_db() {
-	url_obj, err := digo.Provide("main.db.url")
-	if err != nil {
-		panic(err)
-	}
-	url := url_obj.(string)
-	main_db_obj := NewDb(url)
-	digo.RegisterSingleton("main.db", main_db_obj)
+// init_main_redis registers the lazy singleton object with ID main.redis into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.redis")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Redis](c, "main.redis")` to retrieve it as its actual type.
+// The typed getter ProvideMainRedis() retrieves it from the default container as well.
+func init_main_redis(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.redis", Type: "*Redis", Constructor: "NewRedis", Package: "github.com/werbenhu/digo/examples/simple", Scope: "singleton", Lazy: true})
+	c.RegisterLazy("main.redis", func(c *digo.Container) (any, error) {
+		env_vars := digo.NewEnvironment("NewRedis")
+		addr_dep := digo.LookupEnv[string](env_vars, "REDIS_ADDR", false, "localhost:6379")
+		err := env_vars.Err()
+		if err != nil {
+			return nil, err
+		}
+		return NewRedis(addr_dep), nil
+	})
+}
+
+// init_main_app registers the lazy singleton object with ID main.app into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("main.app")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*App](c, "main.app")` to retrieve it as its actual type.
+// The typed getter ProvideMainApp() retrieves it from the default container as well.
+func init_main_app(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "main.app", Type: "*App", Constructor: "NewApp", Package: "github.com/werbenhu/digo/examples/simple", Scope: "singleton", Lazy: true, Dependencies: []string{"main.db", "main.redis", "main.cache"}})
+	c.RegisterLazy("main.app", func(c *digo.Container) (any, error) {
+		db_dep, err := digo.ProvideFrom[*Db](c, "main.db")
+		if err != nil {
+			return nil, err
+		}
+		redis_dep, err := digo.ProvideFrom[*Redis](c, "main.redis")
+		if err != nil {
+			return nil, err
+		}
+		cache_dep, err := digo.ProvideOptionalFrom[*Cache](c, "main.cache")
+		if err != nil {
+			return nil, err
+		}
+		return NewApp(db_dep, redis_dep, cache_dep), nil
+	})
 }
 
-// init_main_redis registers the singleton object with ID main.redis into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("main.redis")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_main_redis() {
-	main_redis_obj := NewRedis()
-	digo.RegisterSingleton("main.redis", main_redis_obj)
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	init_main_db(c)
+	init_main_redis(c)
+	init_main_app(c)
 }
 
-// init_main_app registers the singleton object with ID main.app into the DI object manager
-// Now you can retrieve the singleton object by using `obj, err := digo.Provide("main.app")`.
-// The obj obtained from the above code is of type `any`.
-// You will need to forcefully cast the obj to its corresponding actual object type.
-func init_main_app() {
-	db_obj, err := digo.Provide("main.db")
+// init registers all providers in the current package into the default container.
+func init() {
+	Register(digo.Default())
+}
+
+// ProvideMainDb returns the singleton object with ID main.db.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Db]("main.db")` to handle the error instead.
+func ProvideMainDb() *Db {
+	obj, err := digo.ProvideAs[*Db]("main.db")
 	if err != nil {
 		panic(err)
 	}
-	db := db_obj.(*Db)
-	redis_obj, err := digo.Provide("main.redis")
+	return obj
+}
+
+// ProvideMainRedis returns the singleton object with ID main.redis.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Redis]("main.redis")` to handle the error instead.
+func ProvideMainRedis() *Redis {
+	obj, err := digo.ProvideAs[*Redis]("main.redis")
 	if err != nil {
 		panic(err)
 	}
-	redis := redis_obj.(*Redis)
-	main_app_obj := NewApp(db, redis)
-	digo.RegisterSingleton("main.app", main_app_obj)
+	return obj
 }
 
-// init registers all providers in the current package into the DI object manager.
-func init() {
-	init_main_db_url()
-	init_main_db()
-	init_main_redis()
-	init_main_app()
+// ProvideMainApp returns the singleton object with ID main.app.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*App]("main.app")` to handle the error instead.
+func ProvideMainApp() *App {
+	obj, err := digo.ProvideAs[*App]("main.app")
+	if err != nil {
+		panic(err)
+	}
+	return obj
 }
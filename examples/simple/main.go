@@ -1,58 +1,81 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/werbenhu/digo"
 )
 
-// @provider({"id":"main.db.url"})
-func NewDbUrl() string {
-	return "localhost:3306"
-}
-
 type Db struct {
 	url string
 }
 
 // @provider({"id":"main.db"})
-// @inject({"param":"url", "id":"main.db.url"})
-func NewDb(url string) *Db {
+// @value({"param":"url", "key":"db.url", "default":"localhost:3306"})
+func NewDb(url string) (*Db, error) {
+	if len(url) == 0 {
+		return nil, errors.New("db url is empty")
+	}
 	return &Db{
 		url: url,
-	}
+	}, nil
 }
 
 type Redis struct {
+	addr string
 }
 
 // @provider({"id":"main.redis"})
-func NewRedis() *Redis {
-	return &Redis{}
+// @env({"param":"addr", "name":"REDIS_ADDR", "default":"localhost:6379"})
+func NewRedis(addr string) *Redis {
+	return &Redis{
+		addr: addr,
+	}
+}
+
+// Cache has no provider in this example, so it is injected as nil.
+type Cache struct {
 }
 
 type App struct {
 	db    *Db
 	redis *Redis
+	cache *Cache
 }
 
 // @provider({"id":"main.app"})
 // @inject({"param":"db", "id":"main.db"})
 // @inject({"param":"redis", "id":"main.redis"})
-func NewApp(db *Db, redis *Redis) *App {
+// @inject({"param":"cache", "id":"main.cache", "optional":true})
+func NewApp(db *Db, redis *Redis, cache *Cache) *App {
 	return &App{
 		db:    db,
 		redis: redis,
+		cache: cache,
 	}
 }
 
 func (a *App) Start() {
-	log.Printf("app strat, db:%s\n", a.db.url)
+	log.Printf("app strat, db:%s, redis:%s, cache enabled:%t\n", a.db.url, a.redis.addr, a.cache != nil)
 }
 
 func main() {
-	app, err := digo.Provide("main.app")
-	if err == nil {
-		app.(*App).Start()
+	// Report every provider which failed to be initialized, instead of panicking in init()
+	if err := digo.Init(); err != nil {
+		log.Fatal(err)
 	}
+	defer digo.Close(context.Background())
+
+	// The configuration values are looked up in the source, e.g. DB_URL=mysql:3306 go run .
+	// The providers injected with configuration values are created lazily after the source is set.
+	digo.SetConfigSource(digo.ConfigSourceFunc(func(key string) (string, bool) {
+		return os.LookupEnv(strings.ToUpper(strings.ReplaceAll(key, ".", "_")))
+	}))
+
+	app := ProvideMainApp()
+	app.Start()
 }
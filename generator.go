@@ -11,6 +11,8 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -60,6 +62,14 @@ func newCallExpr(fn ast.Expr, args []ast.Expr) *ast.CallExpr {
 	}
 }
 
+// newIndexExpr creates a new ast.IndexExpr which instantiates the generic function with the given type.
+func newIndexExpr(fn ast.Expr, typ ast.Expr) *ast.IndexExpr {
+	return &ast.IndexExpr{
+		X:     fn,
+		Index: typ,
+	}
+}
+
 // newExprs creates a new slice of ast.Expr with the given expressions.
 func newExprs(exprs ...ast.Expr) []ast.Expr {
 	rets := make([]ast.Expr, len(exprs))
@@ -67,11 +77,11 @@ func newExprs(exprs ...ast.Expr) []ast.Expr {
 	return rets
 }
 
-// newBasicLit creates a new ast.BasicLit with the given value.
+// newBasicLit creates a new ast.BasicLit of the string literal with the given value, which is quoted and escaped.
 func newBasicLit(val string) *ast.BasicLit {
 	return &ast.BasicLit{
 		Kind:  token.STRING,
-		Value: "\"" + val + "\"",
+		Value: strconv.Quote(val),
 	}
 }
 
@@ -95,6 +105,45 @@ func newErrCheckStmt() ast.Stmt {
 	}
 }
 
+// newFailStmt creates a new error check statement which records the error by calling the fail function
+// with the given arguments and the err variable, and then returns, instead of panicking.
+// newFailStmt 创建一个错误检查语句，它调用fail函数记录错误然后返回，而不是panic
+func newFailStmt(failFunction string, args ...ast.Expr) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  newIdent("err"),
+			Op: token.NEQ,
+			Y:  newIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: newCallExpr(newSelectorExpr(failFunction), append(args, newIdent("err"))),
+				},
+				&ast.ReturnStmt{},
+			},
+		},
+	}
+}
+
+// newErrReturnStmt creates a new error check statement which returns the error to the caller.
+func newErrReturnStmt() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  newIdent("err"),
+			Op: token.NEQ,
+			Y:  newIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: newExprs(newIdent("nil"), newIdent("err")),
+				},
+			},
+		},
+	}
+}
+
 func newImportSpec(path, alias string) *ast.ImportSpec {
 	spec := &ast.ImportSpec{
 		Path: newBasicLit(path),
@@ -116,16 +165,40 @@ func objName(prefix string) string {
 // Generator is a code generator for dependency injection.
 type Generator struct {
 	Package         *DiPackage
-	CalledInitFuncs []ast.Stmt          // Initialization functions for singleton objects
+	CalledInitFuncs []ast.Stmt          // Initialization functions for singleton objects, called in Register(c)
 	Fset            *token.FileSet      // FileSet for token positions
 	Decls           []ast.Decl          // Functions generated by the provider
 	ImportSpecs     map[string]ast.Spec // Packages to be imported
 
-	ManagerPackage    string
-	RegisterFunction  string
-	ProvideFunction   string
-	GroupFunction     string
-	GeneratedFileName string
+	ManagerPackage      string
+	ContainerName       string
+	ContainerType       string
+	DefaultFunction     string
+	EntryFunction       string
+	RegisterFunction    string
+	LazyFunction        string
+	FallbackFunction    string
+	PrototypeFunction   string
+	ScopedFunction      string
+	ProfileFunction     string
+	ProvideFunction     string
+	DescribeFunction    string
+	MetadataType        string
+	GroupFunction       string
+	GroupWithFunction   string
+	MemberOptionsType   string
+	ProvideAsFunction   string
+	ProvideFromFunction string
+	OptionalFunction    string
+	ValueFunction       string
+	EnvironmentFunction string
+	EnvFunction         string
+	BindConfigFunction  string
+	MembersOfFunction   string
+	MembersFromFunction string
+	FailFunction        string
+	FailMemberFunction  string
+	GeneratedFileName   string
 }
 
 // NewGenerator creates a new Generator with the given path, package name, and filename.
@@ -137,16 +210,51 @@ func NewGenerator(pkg *DiPackage) *Generator {
 		Decls:           make([]ast.Decl, 0),
 		ImportSpecs:     make(map[string]ast.Spec),
 
-		ManagerPackage:    "github.com/werbenhu/digo",
-		RegisterFunction:  "digo.RegisterSingleton",
-		ProvideFunction:   "digo.Provide",
-		GroupFunction:     "digo.RegisterMember",
-		GeneratedFileName: "digo.generated.go",
+		ManagerPackage:      "github.com/werbenhu/digo",
+		ContainerName:       "c",
+		ContainerType:       "digo.Container",
+		DefaultFunction:     "digo.Default",
+		EntryFunction:       "Register",
+		RegisterFunction:    "c.RegisterSingleton",
+		LazyFunction:        "c.RegisterLazy",
+		FallbackFunction:    "c.RegisterFallback",
+		PrototypeFunction:   "c.RegisterPrototype",
+		ScopedFunction:      "c.RegisterScoped",
+		ProfileFunction:     "c.RegisterProfile",
+		ProvideFunction:     "c.Provide",
+		DescribeFunction:    "c.Describe",
+		MetadataType:        "digo.Metadata",
+		GroupFunction:       "c.RegisterMember",
+		GroupWithFunction:   "c.RegisterMemberWith",
+		MemberOptionsType:   "digo.MemberOptions",
+		ProvideAsFunction:   "digo.ProvideAs",
+		ProvideFromFunction: "digo.ProvideFrom",
+		OptionalFunction:    "digo.ProvideOptionalFrom",
+		ValueFunction:       "digo.ValueFrom",
+		EnvironmentFunction: "digo.NewEnvironment",
+		EnvFunction:         "digo.LookupEnv",
+		BindConfigFunction:  "digo.BindConfig",
+		MembersOfFunction:   "digo.MembersOf",
+		MembersFromFunction: "digo.MembersFrom",
+		FailFunction:        "c.Fail",
+		FailMemberFunction:  "c.FailMember",
+		GeneratedFileName:   "digo.generated.go",
+	}
+}
+
+// containerParams returns the parameter list of the generated functions which receive the container, i.e. (c *digo.Container).
+func (g *Generator) containerParams() *ast.FieldList {
+	return &ast.FieldList{
+		List: []*ast.Field{{
+			Names: []*ast.Ident{newIdent(g.ContainerName)},
+			Type:  newStarExpr(g.ContainerType),
+		}},
 	}
 }
 
 // defineInjectStmts analyzes the inject annotation and generates the corresponding code segment based on the annotation information.
-func (g *Generator) defineInjectStmts(inject *Injector) []ast.Stmt {
+// The errCheck statement is generated after providing the object to handle the error.
+func (g *Generator) defineInjectStmts(inject *Injector, errCheck ast.Stmt) []ast.Stmt {
 	stmts := make([]ast.Stmt, 0)
 
 	// Add import statement if the package is specified.
@@ -154,65 +262,420 @@ func (g *Generator) defineInjectStmts(inject *Injector) []ast.Stmt {
 		g.addImport(inject.Pkg, inject.Alias)
 	}
 
-	// Generate assignment statements for providing the object and handling the error.
-	stmts = append(stmts,
+	// An injected group is built as a typed slice of its members, e.g. ctrls, err := digo.MembersFrom[Controller](c, "controllers"),
+	// which fails if any member is not of the element type.
+	// 注入的组会被构建为成员的带类型的切片，如果有成员不是元素类型则会失败
+	if len(inject.Group) > 0 {
+		return append(stmts,
+			&ast.AssignStmt{
+				Lhs: newExprs(newIdent(inject.GetArgName()), newIdent("err")),
+				Tok: token.DEFINE,
+				Rhs: newExprs(
+					newCallExpr(
+						newIndexExpr(newSelectorExpr(g.MembersFromFunction), inject.elem()),
+						[]ast.Expr{newIdent(g.ContainerName), newBasicLit(inject.Group)},
+					),
+				),
+			},
+			errCheck,
+		)
+	}
+
+	// A configuration value is looked up in the configuration source of the container and converted to the parameter's type,
+	// e.g. url, err := digo.ValueFrom[string](c, "db.url", "localhost:3306").
+	// 配置项从容器的配置源中查找，并转换为参数的类型
+	if len(inject.Key) > 0 {
+		args := newExprs(newIdent(g.ContainerName), newBasicLit(inject.Key))
+		if inject.Default != nil {
+			args = append(args, newBasicLit(*inject.Default))
+		}
+		return append(stmts,
+			&ast.AssignStmt{
+				Lhs: newExprs(newIdent(inject.GetArgName()), newIdent("err")),
+				Tok: token.DEFINE,
+				Rhs: newExprs(newCallExpr(newIndexExpr(newSelectorExpr(g.ValueFunction), inject.Typ), args)),
+			},
+			errCheck,
+		)
+	}
+
+	// An optional object is provided as the zero value of its type if the provider does not exist,
+	// e.g. cache, err := digo.ProvideOptionalFrom[*Cache](c, "main.cache").
+	// 可选的对象在provider不存在时会以其类型的零值提供
+	if inject.Optional {
+		return append(stmts,
+			&ast.AssignStmt{
+				Lhs: newExprs(newIdent(inject.GetArgName()), newIdent("err")),
+				Tok: token.DEFINE,
+				Rhs: newExprs(
+					newCallExpr(
+						newIndexExpr(newSelectorExpr(g.OptionalFunction), inject.Typ),
+						[]ast.Expr{newIdent(g.ContainerName), newBasicLit(inject.ProviderId)},
+					),
+				),
+			},
+			errCheck,
+		)
+	}
+
+	// Generate assignment statements for providing the object converted to the parameter's type and handling the error,
+	// e.g. db, err := digo.ProvideFrom[*Db](c, "main.db"), so an object of another type is reported instead of panicking.
+	// 生成获取转换为参数类型的对象以及处理错误的语句，这样其他类型的对象会被作为错误报告，而不是panic
+	return append(stmts,
 		&ast.AssignStmt{
-			Lhs: newExprs(newIdent(objName(inject.Param)), newIdent("err")),
+			Lhs: newExprs(newIdent(inject.GetArgName()), newIdent("err")),
 			Tok: token.DEFINE,
 			Rhs: newExprs(
 				newCallExpr(
-					newSelectorExpr(g.ProvideFunction),
-					[]ast.Expr{newBasicLit(inject.ProviderId)},
+					newIndexExpr(newSelectorExpr(g.ProvideFromFunction), inject.Typ),
+					[]ast.Expr{newIdent(g.ContainerName), newBasicLit(inject.ProviderId)},
 				),
 			),
 		},
-		newErrCheckStmt(),
-		&ast.AssignStmt{
-			Lhs: newExprs(newIdent(inject.Param)),
+		errCheck,
+	)
+}
+
+// defineEnvStmts generates the statements which look up the environment variables injected into the constructor,
+// and check the errors of all the variables together, e.g.
+//
+//	env_vars := digo.NewEnvironment("NewServer")
+//	port := digo.LookupEnv[int](env_vars, "APP_PORT", true)
+//	err := env_vars.Err()
+//
+// It returns no statements if no environment variable is injected.
+func (g *Generator) defineEnvStmts(fn *DiFunc, errCheck ast.Stmt) []ast.Stmt {
+	stmts := make([]ast.Stmt, 0)
+	env := "env_vars"
+	for _, inject := range fn.Injectors {
+		if len(inject.Env) == 0 {
+			continue
+		}
+		if len(inject.Pkg) > 0 {
+			g.addImport(inject.Pkg, inject.Alias)
+		}
+		args := newExprs(newIdent(env), newBasicLit(inject.Env), newIdent(strconv.FormatBool(inject.Required)))
+		if inject.Default != nil {
+			args = append(args, newBasicLit(*inject.Default))
+		}
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs: newExprs(newIdent(inject.GetArgName())),
 			Tok: token.DEFINE,
-			Rhs: newExprs(&ast.TypeAssertExpr{
-				X:    newIdent(objName(inject.Param)),
-				Type: inject.Typ,
-			}),
+			Rhs: newExprs(newCallExpr(newIndexExpr(newSelectorExpr(g.EnvFunction), inject.Typ), args)),
 		})
+	}
+	if len(stmts) == 0 {
+		return stmts
+	}
 
-	return stmts
+	stmts = append([]ast.Stmt{&ast.AssignStmt{
+		Lhs: newExprs(newIdent(env)),
+		Tok: token.DEFINE,
+		Rhs: newExprs(newCallExpr(newSelectorExpr(g.EnvironmentFunction), newExprs(newBasicLit(fn.Name)))),
+	}}, stmts...)
+	return append(stmts, &ast.AssignStmt{
+		Lhs: newExprs(newIdent("err")),
+		Tok: token.DEFINE,
+		Rhs: newExprs(newCallExpr(newSelectorExpr(env+".Err"), newExprs())),
+	}, errCheck)
 }
 
-// defineProviderFunc creates a provider's singleton initialization function and returns an ast.FuncDecl object.
-func (g *Generator) defineProviderFunc(fn *DiFunc) *ast.FuncDecl {
-	stmts := make([]ast.Stmt, 0)
+// defineProviderCall generates the statements which provide the injected objects and call the provider's constructor,
+// it returns the statements and the call expression of the constructor.
+func (g *Generator) defineProviderCall(fn *DiFunc, errCheck ast.Stmt) ([]ast.Stmt, *ast.CallExpr) {
 	args := make([]ast.Expr, 0)
 
+	// The environment variables are looked up first, so that all the missing ones are reported together.
+	// 首先查找环境变量，以便一起报告所有缺失的环境变量
+	stmts := g.defineEnvStmts(fn, errCheck)
+
 	// Generate function arguments and inject statements if there are injectors.
 	for _, inject := range fn.Injectors {
 		args = append(args, newIdent(inject.GetArgName()))
-		stmts = append(stmts, g.defineInjectStmts(inject)...)
+		if len(inject.Env) == 0 {
+			stmts = append(stmts, g.defineInjectStmts(inject, errCheck)...)
+		}
 	}
+	return stmts, newCallExpr(newIdent(fn.Name), args)
+}
 
-	// Generate assignment statements for calling the provider function, defining the object, and registering it as a singleton.
-	stmts = append(stmts, &ast.AssignStmt{
-		Lhs: newExprs(newIdent(fn.providerObjName())),
+// defineObjectStmts generates the statements which provide the injected objects and assign the object created
+// by the constructor to the variable named obj. If the constructor returns an error, the error is checked as well.
+// The errCheck statement handles the errors of both the injected objects and the constructor.
+func (g *Generator) defineObjectStmts(fn *DiFunc, obj string, errCheck ast.Stmt) []ast.Stmt {
+	stmts, call := g.defineProviderCall(fn, errCheck)
+
+	if fn.ReturnsErr {
+		return append(stmts, &ast.AssignStmt{
+			Lhs: newExprs(newIdent(obj), newIdent("err")),
+			Tok: token.DEFINE,
+			Rhs: newExprs(call),
+		}, errCheck)
+	}
+	return append(stmts, &ast.AssignStmt{
+		Lhs: newExprs(newIdent(obj)),
 		Tok: token.DEFINE,
-		Rhs: newExprs(newCallExpr(newIdent(fn.Name), args)),
-	}, &ast.ExprStmt{
+		Rhs: newExprs(call),
+	})
+}
+
+// defineSingletonStmts generates the statements which create the provider's object and register it as a singleton.
+func (g *Generator) defineSingletonStmts(fn *DiFunc) []ast.Stmt {
+	// A failure is recorded into the container with the provider ID instead of panicking.
+	// 失败会以provider的ID记录到容器中，而不是panic
+	stmts := g.defineObjectStmts(fn, fn.providerObjName(), newFailStmt(g.FailFunction, newBasicLit(fn.ProviderId)))
+
+	// Generate statements for registering the object as a singleton.
+	return append(stmts, &ast.ExprStmt{
 		X: newCallExpr(newSelectorExpr(g.RegisterFunction), newExprs(
 			newBasicLit(fn.ProviderId),
 			newIdent(fn.providerObjName())),
 		),
 	})
+}
 
-	comments := []string{
-		fmt.Sprintf("\n// %s registers the singleton object with ID %s into the DI object manager", fn.providerFuncName(), fn.ProviderId),
-		fmt.Sprintf("// Now you can retrieve the singleton object by using `obj, err := digo.Provide(\"%s\")`.", fn.ProviderId),
-		"// The obj obtained from the above code is of type `any`.",
-		"// You will need to forcefully cast the obj to its corresponding actual object type.",
+// defineFactoryLit generates a function literal of type digo.Factory, which provides the injected objects
+// from the container passed to the factory and calls the provider's constructor.
+func (g *Generator) defineFactoryLit(fn *DiFunc) *ast.FuncLit {
+	var stmts []ast.Stmt
+	var results []ast.Expr
+
+	if len(fn.ConfigPrefix) > 0 {
+		stmts, results = g.defineBindConfigStmts(fn)
+	} else {
+		var call *ast.CallExpr
+		stmts, call = g.defineProviderCall(fn, newErrReturnStmt())
+
+		// If the constructor returns an error, its results are returned directly, the error is wrapped by the container.
+		// 如果构造函数返回了error，则直接返回构造函数的结果，容器会对错误进行包装
+		results = newExprs(call, newIdent("nil"))
+		if fn.ReturnsErr {
+			results = newExprs(call)
+		}
+	}
+	stmts = append(stmts, &ast.ReturnStmt{Results: results})
+
+	return &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params: g.containerParams(),
+			Results: &ast.FieldList{
+				List: []*ast.Field{{Type: newIdent("any")}, {Type: newIdent("error")}},
+			},
+		},
+		Body: &ast.BlockStmt{List: stmts},
+	}
+}
+
+// defineBindConfigStmts generates the statements which create the object bound to the configuration file,
+// and the results which populate it with the section of the file, e.g. digo.BindConfig(c, "database", &DbConfig{}),
+// or digo.BindConfig(c, "database", NewDbConfig()) for a provider, whose object is used as the base of the binding.
+// defineBindConfigStmts 生成创建绑定到配置文件的对象的语句，以及使用配置文件中的配置段填充该对象的返回值，
+// 对于provider，它创建的对象会作为绑定的基础
+func (g *Generator) defineBindConfigStmts(fn *DiFunc) ([]ast.Stmt, []ast.Expr) {
+	var stmts []ast.Stmt
+	var base ast.Expr
+
+	if fn.IsType {
+		base = &ast.UnaryExpr{Op: token.AND, X: &ast.CompositeLit{Type: newIdent(fn.Name)}}
+	} else {
+		var call *ast.CallExpr
+		stmts, call = g.defineProviderCall(fn, newErrReturnStmt())
+		base = call
+
+		// The error of the constructor is returned before the binding.
+		// 构造函数的错误在绑定之前返回
+		if fn.ReturnsErr {
+			stmts = append(stmts, &ast.AssignStmt{
+				Lhs: newExprs(newIdent(fn.providerObjName()), newIdent("err")),
+				Tok: token.DEFINE,
+				Rhs: newExprs(call),
+			}, newErrReturnStmt())
+			base = newIdent(fn.providerObjName())
+		}
+	}
+
+	return stmts, newExprs(newCallExpr(newSelectorExpr(g.BindConfigFunction), newExprs(
+		newIdent(g.ContainerName),
+		newBasicLit(fn.ConfigPrefix),
+		base,
+	)))
+}
+
+// defineFactoryStmts generates the statements which register the provider's factory by calling the register function,
+// e.g. as a lazy singleton or as a prototype.
+func (g *Generator) defineFactoryStmts(fn *DiFunc, registerFunction string) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.ExprStmt{
+			X: newCallExpr(newSelectorExpr(registerFunction), newExprs(
+				newBasicLit(fn.ProviderId),
+				g.defineFactoryLit(fn)),
+			),
+		},
+	}
+}
+
+// defineFallbackStmts generates the statement which registers the fallback provider of the scope, e.g.
+// c.RegisterFallback("main.cache", "singleton", func(c *digo.Container) (any, error) {...}).
+// defineFallbackStmts 生成注册指定作用域的后备provider的语句
+func (g *Generator) defineFallbackStmts(fn *DiFunc) []ast.Stmt {
+	scope := fn.Scope
+	if len(scope) == 0 {
+		scope = "singleton"
+	}
+	return []ast.Stmt{
+		&ast.ExprStmt{
+			X: newCallExpr(newSelectorExpr(g.FallbackFunction), newExprs(
+				newBasicLit(fn.ProviderId),
+				newBasicLit(scope),
+				g.defineFactoryLit(fn)),
+			),
+		},
+	}
+}
+
+// defineDescribeStmt generates the statement which describes the metadata of the provider or the group member,
+// e.g. c.Describe(digo.Metadata{Id: "main.db", Type: "*Db", Constructor: "NewDb", ...}).
+// defineDescribeStmt 生成描述provider或者组成员元数据的语句
+func (g *Generator) defineDescribeStmt(fn *DiFunc) ast.Stmt {
+	elts := make([]ast.Expr, 0)
+	field := func(key string, value ast.Expr) {
+		elts = append(elts, &ast.KeyValueExpr{Key: newIdent(key), Value: value})
+	}
+	stringsLit := func(vals []string) ast.Expr {
+		lits := make([]ast.Expr, len(vals))
+		for i, val := range vals {
+			lits[i] = newBasicLit(val)
+		}
+		return &ast.CompositeLit{Type: &ast.ArrayType{Elt: newIdent("string")}, Elts: lits}
+	}
+
+	if len(fn.ProviderId) > 0 {
+		field("Id", newBasicLit(fn.ProviderId))
+	}
+	if fn.Result != nil {
+		field("Type", newBasicLit(fn.Result.String()))
+	}
+	if !fn.IsType {
+		field("Constructor", newBasicLit(fn.Name))
+	}
+	if fn.Package != nil {
+		field("Package", newBasicLit(fn.Package.Path))
+	}
+	if len(fn.ProviderId) > 0 {
+		scope := fn.Scope
+		if len(scope) == 0 {
+			scope = "singleton"
+		}
+		field("Scope", newBasicLit(scope))
+		if fn.Lazy {
+			field("Lazy", newIdent("true"))
+		}
+		if len(fn.Profile) > 0 {
+			field("Profile", newBasicLit(fn.Profile))
+		}
+		if fn.OnMissing {
+			field("Fallback", newIdent("true"))
+		}
+	}
+	deps := make([]string, 0)
+	groupDeps := make([]string, 0)
+	for _, inject := range fn.Injectors {
+		if inject.isConfig() {
+			continue
+		}
+		if len(inject.Group) > 0 {
+			groupDeps = append(groupDeps, inject.Group)
+		} else {
+			deps = append(deps, inject.ProviderId)
+		}
+	}
+	if len(deps) > 0 {
+		field("Dependencies", stringsLit(deps))
+	}
+	if len(groupDeps) > 0 {
+		field("GroupDependencies", stringsLit(groupDeps))
+	}
+	if len(fn.GroupId) > 0 {
+		field("Groups", stringsLit([]string{fn.GroupId}))
+	}
+	if len(fn.MemberName) > 0 {
+		field("Name", newBasicLit(fn.MemberName))
+	}
+
+	return &ast.ExprStmt{
+		X: newCallExpr(newSelectorExpr(g.DescribeFunction), newExprs(&ast.CompositeLit{
+			Type: newSelectorExpr(g.MetadataType),
+			Elts: elts,
+		})),
+	}
+}
+
+// defineProviderFunc creates a provider's singleton initialization function and returns an ast.FuncDecl object.
+func (g *Generator) defineProviderFunc(fn *DiFunc) *ast.FuncDecl {
+	var stmts []ast.Stmt
+	var comments []string
+
+	if fn.OnMissing {
+		stmts = g.defineFallbackStmts(fn)
+		comments = []string{
+			fmt.Sprintf("\n// %s registers the fallback with ID %s into the container c", fn.providerFuncName(), fn.ProviderId),
+			"// It yields to any other provider of the ID, even one registered by a package generated separately.",
+			fmt.Sprintf("// Now you can retrieve the object by using `obj, err := c.Provide(\"%s\")`.", fn.ProviderId),
+		}
+	} else if fn.isPrototype() {
+		stmts = g.defineFactoryStmts(fn, g.PrototypeFunction)
+		comments = []string{
+			fmt.Sprintf("\n// %s registers the prototype with ID %s into the container c", fn.providerFuncName(), fn.ProviderId),
+			"// A new object is created every time it is provided.",
+			fmt.Sprintf("// Now you can retrieve a new object by using `obj, err := c.Provide(\"%s\")`.", fn.ProviderId),
+		}
+	} else if fn.isRequestScoped() {
+		stmts = g.defineFactoryStmts(fn, g.ScopedFunction)
+		comments = []string{
+			fmt.Sprintf("\n// %s registers the request scoped provider with ID %s into the container c", fn.providerFuncName(), fn.ProviderId),
+			"// One object is created for each child scope created by c.NewScope().",
+			fmt.Sprintf("// Now you can retrieve the object of a scope by using `obj, err := scope.Provide(\"%s\")`.", fn.ProviderId),
+		}
+	} else if fn.Lazy {
+		stmts = g.defineFactoryStmts(fn, g.LazyFunction)
+		comments = []string{
+			fmt.Sprintf("\n// %s registers the lazy singleton object with ID %s into the container c", fn.providerFuncName(), fn.ProviderId),
+			"// The object and the lazy objects it depends on are created on the first time it is provided.",
+			fmt.Sprintf("// Now you can retrieve the singleton object by using `obj, err := c.Provide(\"%s\")`.", fn.ProviderId),
+		}
+	} else {
+		stmts = g.defineSingletonStmts(fn)
+		comments = []string{
+			fmt.Sprintf("\n// %s registers the singleton object with ID %s into the container c", fn.providerFuncName(), fn.ProviderId),
+			fmt.Sprintf("// Now you can retrieve the singleton object by using `obj, err := c.Provide(\"%s\")`.", fn.ProviderId),
+		}
+	}
+
+	// Describe the provider, the dependencies are recorded so that the container disposes the object before its dependencies.
+	// The provider of a profile is described by Register(c) instead, so that it is known before the profile is activated.
+	// 描述provider，其中记录的依赖可以让容器在释放依赖之前释放该对象。profile的provider则由Register(c)描述，以便在profile激活之前就能获知
+	if len(fn.Profile) == 0 {
+		stmts = append([]ast.Stmt{g.defineDescribeStmt(fn)}, stmts...)
+	}
+
+	// The typed ways to retrieve the object are pointed out, since the obj above is of type any.
+	// 指出获取带类型的对象的方式，因为上面的obj是any类型的
+	if fn.Result != nil {
+		container := g.ContainerName
+		if fn.isRequestScoped() {
+			container = "scope"
+		}
+		comments = append(comments, fmt.Sprintf("// The obj is of type `any`, use `digo.ProvideFrom[%s](%s, \"%s\")` to retrieve it as its actual type.",
+			fn.Result, container, fn.ProviderId))
+	}
+	if g.hasProviderGetter(fn) {
+		comments = append(comments, fmt.Sprintf("// The typed getter %s() retrieves it from the default container as well.", fn.providerGetterName()))
 	}
 
 	return &ast.FuncDecl{
 		Doc:  newCommentGroup(comments),
 		Name: newIdent(fn.providerFuncName()),
-		Type: &ast.FuncType{},
+		Type: &ast.FuncType{Params: g.containerParams()},
 		Body: &ast.BlockStmt{List: stmts},
 	}
 }
@@ -226,13 +689,6 @@ func (g *Generator) defineProviderFuncs() {
 			// Add the initialization function for the singleton object to the ast.File.
 			// For example, if the provider's ID is "xxx", then we add the init_xxx() function to the AST.
 			g.Decls = append(g.Decls, g.defineProviderFunc(fn))
-
-			// The initialization function for the singleton object needs to be called in the init() function.
-			// Store the functions that need to be called in init() in the callFuncsInInit slice.
-			// Later when creating the init() function, we will call these initialization functions for the singleton objects.
-			g.CalledInitFuncs = append(g.CalledInitFuncs, &ast.ExprStmt{
-				X: newCallExpr(newIdent(fn.providerFuncName()), newExprs()),
-			})
 		}
 	}
 }
@@ -245,12 +701,32 @@ func (g *Generator) defineGroupFuncs() {
 			// Add the initialization function for the singleton object to the ast.File.
 			// For example, if the provider's ID is "xxx", then we add the init_xxx() function to the AST.
 			g.Decls = append(g.Decls, g.defineGroupFunc(fn))
+		}
+	}
+}
 
-			// The initialization function for the singleton object needs to be called in the init() function.
-			// Store the functions that need to be called in init() in the callFuncsInInit slice.
-			// Later when creating the init() function, we will call these initialization functions for the singleton objects.
+// defineInitCalls stores the calls of the initialization functions, which need to be called in the Register(c) function,
+// in the CalledInitFuncs slice. The functions are called in the order of their priorities, so that the dependencies
+// of a provider, including the members of the groups injected into it, are registered before the provider.
+// defineInitCalls 将需要在Register(c)函数中调用的初始化函数保存到CalledInitFuncs中，初始化函数按照优先级的顺序调用，
+// 以便provider的依赖，包括注入的组的成员，在provider之前注册
+func (g *Generator) defineInitCalls() {
+	for _, fn := range g.Package.Funcs {
+		// The provider of a profile is described, and registered only if the profile is active,
+		// e.g. c.RegisterProfile("dev", init_main_mailer_dev).
+		// profile的provider会被描述，并且只有在profile激活时才会注册
+		if len(fn.ProviderId) > 0 && len(fn.Profile) > 0 {
+			g.CalledInitFuncs = append(g.CalledInitFuncs, g.defineDescribeStmt(fn), &ast.ExprStmt{
+				X: newCallExpr(newSelectorExpr(g.ProfileFunction), newExprs(newBasicLit(fn.Profile), newIdent(fn.providerFuncName()))),
+			})
+		} else if len(fn.ProviderId) > 0 {
+			g.CalledInitFuncs = append(g.CalledInitFuncs, &ast.ExprStmt{
+				X: newCallExpr(newIdent(fn.providerFuncName()), newExprs(newIdent(g.ContainerName))),
+			})
+		}
+		if len(fn.GroupId) > 0 {
 			g.CalledInitFuncs = append(g.CalledInitFuncs, &ast.ExprStmt{
-				X: newCallExpr(newIdent(fn.groupFuncName()), newExprs()),
+				X: newCallExpr(newIdent(fn.groupFuncName()), newExprs(newIdent(g.ContainerName))),
 			})
 		}
 	}
@@ -264,10 +740,38 @@ func (g *Generator) addImport(pkg string, alias string) {
 	}
 }
 
+// defineMemberOptions generates the fields of the digo.MemberOptions of the member, which are used to look up
+// the member by its name and to sort the members of the group deterministically.
+// defineMemberOptions 生成组成员的digo.MemberOptions的字段，用于根据名字查找成员以及对组成员进行确定的排序
+func (g *Generator) defineMemberOptions(fn *DiFunc) []ast.Expr {
+	elts := make([]ast.Expr, 0)
+	field := func(key string, value ast.Expr) {
+		elts = append(elts, &ast.KeyValueExpr{Key: newIdent(key), Value: value})
+	}
+
+	if len(fn.MemberName) > 0 {
+		field("Name", newBasicLit(fn.MemberName))
+	}
+	if fn.MemberOrder != 0 {
+		field("Order", &ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(fn.MemberOrder)})
+	}
+	if len(fn.ProviderId) > 0 {
+		field("Id", newBasicLit(fn.ProviderId))
+	}
+	if len(fn.Source) > 0 {
+		field("Source", newBasicLit(fn.Source))
+		field("Line", &ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(fn.Line)})
+	}
+	return elts
+}
+
 // defineGroupFunc creates a group's member initialization function and returns an ast.FuncDecl object.
 func (g *Generator) defineGroupFunc(fn *DiFunc) *ast.FuncDecl {
 	stmts := make([]ast.Stmt, 0)
-	args := make([]ast.Expr, 0)
+
+	// A failure is recorded into the container with the group ID and the function name instead of panicking.
+	// 失败会以组的ID和函数名记录到容器中，而不是panic
+	failStmt := newFailStmt(g.FailMemberFunction, newBasicLit(fn.GroupId), newBasicLit(fn.Name))
 
 	if len(fn.ProviderId) > 0 {
 		// Generate assignment statement for providing the member object and handling the error.
@@ -277,59 +781,201 @@ func (g *Generator) defineGroupFunc(fn *DiFunc) *ast.FuncDecl {
 				Tok: token.DEFINE,
 				Rhs: newExprs(
 					newCallExpr(
-						newSelectorExpr(g.GroupFunction),
+						newSelectorExpr(g.ProvideFunction),
 						[]ast.Expr{newBasicLit(fn.ProviderId)},
 					),
 				),
 			},
-			newErrCheckStmt(),
+			failStmt,
 		)
 	} else {
-		// Generate arguments and inject statements for member initialization.
-		for _, inject := range fn.Injectors {
-			args = append(args, newIdent(inject.Param))
-			stmts = append(stmts, g.defineInjectStmts(inject)...)
-		}
-		stmts = append(stmts, &ast.AssignStmt{
-			Lhs: newExprs(newIdent("member")),
-			Tok: token.DEFINE,
-			Rhs: newExprs(newCallExpr(newIdent(fn.Name), args)),
-		})
-	}
+		// Describe the member, a member which is also a provider is described by the provider's function.
+		// 描述组成员，同时也是provider的组成员由provider的函数进行描述
+		stmts = append(stmts, g.defineDescribeStmt(fn))
 
-	// Register the member object with the group.
-	stmts = append(stmts, &ast.ExprStmt{
-		X: newCallExpr(newSelectorExpr(g.GroupFunction), newExprs(
-			newBasicLit(fn.GroupId),
-			newIdent("member")),
-		),
-	})
+		// Generate inject statements and the constructor call for member initialization.
+		stmts = append(stmts, g.defineObjectStmts(fn, "member", failStmt)...)
+	}
 
 	comments := []string{
-		fmt.Sprintf("\n// Add a member object to group: %s", fn.GroupId),
-		fmt.Sprintf("// Now you can retrieve the group's member objects by using `objs, err := digo.Members(\"%s\")`.", fn.GroupId),
-		"// The objs obtained from the above code are of type `[]any`.",
-		"// You will need to forcefully cast the objs to their corresponding actual object types.",
+		fmt.Sprintf("\n// Add a member object to group %s of the container c", fn.GroupId),
+		fmt.Sprintf("// Now you can retrieve the group's member objects by using `objs, err := c.Members(\"%s\")`.", fn.GroupId),
+	}
+
+	// Register the member object with the group, together with its name, order and source position if any.
+	// 将成员对象注册到组中，同时注册它的名字、顺序以及源码位置
+	if opts := g.defineMemberOptions(fn); len(opts) > 0 {
+		stmts = append(stmts, &ast.ExprStmt{
+			X: newCallExpr(newSelectorExpr(g.GroupWithFunction), newExprs(
+				newBasicLit(fn.GroupId),
+				newIdent("member"),
+				&ast.CompositeLit{
+					Type: newSelectorExpr(g.MemberOptionsType),
+					Elts: opts,
+				}),
+			),
+		})
+	} else {
+		stmts = append(stmts, &ast.ExprStmt{
+			X: newCallExpr(newSelectorExpr(g.GroupFunction), newExprs(
+				newBasicLit(fn.GroupId),
+				newIdent("member")),
+			),
+		})
+	}
+
+	if len(fn.MemberName) > 0 {
+		comments = append(comments,
+			fmt.Sprintf("// The member object is named %s, you can also retrieve it by using `objs, err := c.MemberMap(\"%s\")`.", fn.MemberName, fn.GroupId))
+	}
+	if fn.GroupTyp != nil {
+		comments = append(comments,
+			fmt.Sprintf("// The objs are of type `[]any`, use `digo.MembersFrom[%s](c, \"%s\")` to retrieve them as a typed slice.",
+				fn.GroupTyp, fn.GroupId),
+			fmt.Sprintf("// The typed getter %s() retrieves them from the default container as well.", fn.groupGetterName()))
+	} else {
+		comments = append(comments,
+			fmt.Sprintf("// The objs are of type `[]any`, use `digo.MembersFrom[T](c, \"%s\")` to retrieve them as a slice of the element type T.",
+				fn.GroupId))
 	}
 
 	return &ast.FuncDecl{
 		Doc:  newCommentGroup(comments),
 		Name: newIdent(fn.groupFuncName()),
-		Type: &ast.FuncType{},
+		Type: &ast.FuncType{Params: g.containerParams()},
+		Body: &ast.BlockStmt{List: stmts},
+	}
+}
+
+// defineGetterFunc creates a typed getter function which calls the generic function instantiated with typ,
+// and returns an ast.FuncDecl object whose result type is result.
+func (g *Generator) defineGetterFunc(name string, genericFunction string, id string, typ ast.Expr, result ast.Expr, comments []string) *ast.FuncDecl {
+	stmts := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: newExprs(newIdent("obj"), newIdent("err")),
+			Tok: token.DEFINE,
+			Rhs: newExprs(
+				newCallExpr(
+					newIndexExpr(newSelectorExpr(genericFunction), typ),
+					[]ast.Expr{newBasicLit(id)},
+				),
+			),
+		},
+		newErrCheckStmt(),
+		&ast.ReturnStmt{
+			Results: newExprs(newIdent("obj")),
+		},
+	}
+
+	return &ast.FuncDecl{
+		Doc:  newCommentGroup(comments),
+		Name: newIdent(name),
+		Type: &ast.FuncType{
+			Results: &ast.FieldList{
+				List: []*ast.Field{{Type: result}},
+			},
+		},
 		Body: &ast.BlockStmt{List: stmts},
 	}
 }
 
+// hasProviderGetter returns whether a typed getter is generated for the provider, i.e. its result type is known,
+// it is not request scoped, since it can only be provided from a child scope, and the providers of the same ID
+// in the other profiles declare the same result type.
+// hasProviderGetter 返回是否为provider生成带类型的获取函数，即它的返回值类型已知，不是请求作用域的，因为它只能从子作用域中获取，
+// 并且其他profile中相同ID的provider声明了相同的返回值类型
+func (g *Generator) hasProviderGetter(fn *DiFunc) bool {
+	if len(fn.ProviderId) == 0 || fn.Result == nil || fn.isRequestScoped() {
+		return false
+	}
+	for _, other := range g.Package.Funcs {
+		if other.ProviderId == fn.ProviderId && other.Result != nil && other.Result.String() != fn.Result.String() {
+			return false
+		}
+	}
+	return true
+}
+
+// defineGetterFuncs generates typed getter functions for the providers and the groups whose types are known.
+func (g *Generator) defineGetterFuncs() {
+	// Only one getter is generated for each provider ID, even if the ID has providers of multiple profiles in the current package.
+	// 每个provider ID只生成一个获取函数，即使当前包中有该ID的多个profile的provider
+	provided := make(map[string]bool)
+	for _, fn := range g.Package.Funcs {
+		if g.hasProviderGetter(fn) && !provided[fn.ProviderId] {
+			provided[fn.ProviderId] = true
+			if len(fn.Result.Pkg) > 0 {
+				g.addImport(fn.Result.Pkg, fn.Result.Alias)
+			}
+
+			// For example, if the provider's ID is "main.db" and the constructor returns *Db,
+			// then we add the func ProvideMainDb() *Db function to the AST.
+			object := "the singleton object"
+			if fn.isPrototype() {
+				object = "a new object"
+			}
+			g.Decls = append(g.Decls, g.defineGetterFunc(fn.providerGetterName(), g.ProvideAsFunction, fn.ProviderId,
+				fn.Result.Expr, fn.Result.Expr, []string{
+					fmt.Sprintf("\n// %s returns %s with ID %s.", fn.providerGetterName(), object, fn.ProviderId),
+					fmt.Sprintf("// It panics if the object cannot be provided, use `digo.ProvideAs[%s](\"%s\")` to handle the error instead.",
+						fn.Result, fn.ProviderId),
+				}))
+		}
+	}
+
+	// Only one getter is generated for each group, even if the group has multiple members in the current package.
+	defined := make(map[string]bool)
+	for _, fn := range g.Package.Funcs {
+		if len(fn.GroupId) > 0 && fn.GroupTyp != nil && !defined[fn.GroupId] {
+			defined[fn.GroupId] = true
+			if len(fn.GroupTyp.Pkg) > 0 {
+				g.addImport(fn.GroupTyp.Pkg, fn.GroupTyp.Alias)
+			}
+
+			// For example, if the group's ID is "controllers" and its type is Controller,
+			// then we add the func MembersControllers() []Controller function to the AST.
+			g.Decls = append(g.Decls, g.defineGetterFunc(fn.groupGetterName(), g.MembersOfFunction, fn.GroupId,
+				fn.GroupTyp.Expr, &ast.ArrayType{Elt: fn.GroupTyp.Expr}, []string{
+					fmt.Sprintf("\n// %s returns the member objects of group %s.", fn.groupGetterName(), fn.GroupId),
+					fmt.Sprintf("// It panics if the members cannot be provided, use `digo.MembersOf[%s](\"%s\")` to handle the error instead.",
+						fn.GroupTyp, fn.GroupId),
+				}))
+		}
+	}
+}
+
+// defineEntryFunc generates the code for the Register(c *digo.Container) function as an ast.FuncDecl object.
+func (g *Generator) defineEntryFunc() {
+	decl := &ast.FuncDecl{
+		Doc: newCommentGroup([]string{
+			fmt.Sprintf("\n// %s registers all providers in the current package into the container c.", g.EntryFunction),
+			"// The providers of other packages that they depend on must be registered into c beforehand.",
+		}),
+		Name: newIdent(g.EntryFunction),
+		Type: &ast.FuncType{Params: g.containerParams()},
+		Body: &ast.BlockStmt{
+			List: g.CalledInitFuncs,
+		},
+	}
+	g.Decls = append(g.Decls, decl)
+}
+
 // defineInitFunc generates the code for the init() function as an ast.FuncDecl object.
 func (g *Generator) defineInitFunc() {
 	decl := &ast.FuncDecl{
 		Doc: newCommentGroup([]string{
-			"\n// init registers all providers in the current package into the DI object manager.",
+			"\n// init registers all providers in the current package into the default container.",
 		}),
 		Name: newIdent("init"),
 		Type: &ast.FuncType{},
 		Body: &ast.BlockStmt{
-			List: g.CalledInitFuncs,
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: newCallExpr(newIdent(g.EntryFunction), newExprs(
+						newCallExpr(newSelectorExpr(g.DefaultFunction), newExprs()),
+					)),
+				},
+			},
 		},
 	}
 	g.Decls = append(g.Decls, decl)
@@ -337,9 +983,16 @@ func (g *Generator) defineInitFunc() {
 
 // genAllAstDecls combines all ast.Decl objects into g.decls, where import declarations come before function declarations.
 func (g *Generator) genAllAstDecls() {
+	// The imports are sorted by their keys, so that the generated code is deterministic.
+	// 按key对import进行排序，以便生成的代码是确定的
+	keys := make([]string, 0, len(g.ImportSpecs))
+	for key := range g.ImportSpecs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 	importSpecs := make([]ast.Spec, 0)
-	for _, spec := range g.ImportSpecs {
-		importSpecs = append(importSpecs, spec)
+	for _, key := range keys {
+		importSpecs = append(importSpecs, g.ImportSpecs[key])
 	}
 
 	g.Decls = append([]ast.Decl{&ast.GenDecl{
@@ -391,6 +1044,9 @@ func (g *Generator) Do() {
 	g.addImport(g.ManagerPackage, "")
 	g.defineProviderFuncs()
 	g.defineGroupFuncs()
+	g.defineInitCalls()
+	g.defineEntryFunc()
 	g.defineInitFunc()
+	g.defineGetterFuncs()
 	g.output()
 }
@@ -5,14 +5,24 @@
 package digo
 
 import (
+	"bytes"
+	"flag"
 	"go/ast"
+	"go/format"
 	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
 )
 
+var update = flag.Bool("update", false, "update the golden files")
+
 func TestNewIdent(t *testing.T) {
 	ident := newIdent("name")
 	assert.Equal(t, "name", ident.Name)
@@ -87,6 +97,19 @@ func TestNewErrCheckStmt(t *testing.T) {
 	assert.Equal(t, newIdent("err"), callExpr.Args[0])
 }
 
+func TestNewFailStmt(t *testing.T) {
+	failStmt := newFailStmt("c.Fail", newBasicLit("main.db"))
+	ifStmt, ok := failStmt.(*ast.IfStmt)
+	assert.True(t, ok)
+	assert.Len(t, ifStmt.Body.List, 2)
+
+	// The error is recorded into the container and the function returns instead of panicking
+	exprStmt, ok := ifStmt.Body.List[0].(*ast.ExprStmt)
+	assert.True(t, ok)
+	assert.Equal(t, newCallExpr(newSelectorExpr("c.Fail"), newExprs(newBasicLit("main.db"), newIdent("err"))), exprStmt.X)
+	assert.Equal(t, &ast.ReturnStmt{}, ifStmt.Body.List[1])
+}
+
 func TestNewImportSpec(t *testing.T) {
 	path := "github.com/example/pkg"
 	alias := "pkgalias"
@@ -138,10 +161,10 @@ func TestDefineInjectStmts(t *testing.T) {
 	}
 
 	// Define the inject statements.
-	stmts := g.defineInjectStmts(inject)
+	stmts := g.defineInjectStmts(inject, newErrCheckStmt())
 
 	// Assert the number of statements.
-	assert.Len(t, stmts, 3)
+	assert.Len(t, stmts, 2)
 
 	// Assert ImportSpecs
 	assert.Len(t, g.ImportSpecs, 1)
@@ -152,29 +175,498 @@ func TestDefineInjectStmts(t *testing.T) {
 		Name: newIdent("bus"),
 	}, importSpec)
 
-	// Assert the assignment statement for providing the object.
+	// Assert the assignment statement for providing the object converted to the parameter's type,
+	// a type mismatch is handled by the error check instead of panicking.
 	assert.Equal(t, &ast.AssignStmt{
-		Lhs: newExprs(newIdent(objName("myParam")), newIdent("err")),
+		Lhs: newExprs(newIdent("myParam_dep"), newIdent("err")),
 		Tok: token.DEFINE,
 		Rhs: newExprs(
 			newCallExpr(
-				newSelectorExpr(g.ProvideFunction),
-				[]ast.Expr{newBasicLit("my_provider_id")},
+				newIndexExpr(newSelectorExpr(g.ProvideFromFunction), newIdent("MyType")),
+				[]ast.Expr{newIdent("c"), newBasicLit("my_provider_id")},
 			),
 		),
 	}, stmts[0])
 
 	// Assert the error check statement.
 	assert.Equal(t, newErrCheckStmt(), stmts[1])
+}
+
+func TestDefineGetterFuncs(t *testing.T) {
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	pkg.Funcs = DiFuncs{
+		{
+			Name:       "NewDb",
+			ProviderId: "main.db",
+			Result:     &DiType{Expr: newStarExpr("sql.DB"), Pkg: "database/sql"},
+		},
+		{
+			Name:       "NewUserController",
+			GroupId:    "controllers",
+			GroupTyp:   &DiType{Expr: newIdent("Controller")},
+			ProviderId: "main.user",
+		},
+		{
+			Name:     "NewRoleController",
+			GroupId:  "controllers",
+			GroupTyp: &DiType{Expr: newIdent("Controller")},
+		},
+	}
+
+	g := NewGenerator(pkg)
+	g.defineGetterFuncs()
+
+	// The provider without result type has no getter, and the group only has one getter.
+	assert.Len(t, g.Decls, 2)
+	assert.Contains(t, g.ImportSpecs, "database/sql_")
+
+	provider := g.Decls[0].(*ast.FuncDecl)
+	assert.Equal(t, "ProvideMainDb", provider.Name.Name)
+	assert.Equal(t, newStarExpr("sql.DB"), provider.Type.Results.List[0].Type)
+	call := provider.Body.List[0].(*ast.AssignStmt).Rhs[0].(*ast.CallExpr)
+	assert.Equal(t, newIndexExpr(newSelectorExpr(g.ProvideAsFunction), newStarExpr("sql.DB")), call.Fun)
+	assert.Equal(t, []ast.Expr{newBasicLit("main.db")}, call.Args)
+
+	group := g.Decls[1].(*ast.FuncDecl)
+	assert.Equal(t, "MembersControllers", group.Name.Name)
+	assert.Equal(t, &ast.ArrayType{Elt: newIdent("Controller")}, group.Type.Results.List[0].Type)
+	call = group.Body.List[0].(*ast.AssignStmt).Rhs[0].(*ast.CallExpr)
+	assert.Equal(t, newIndexExpr(newSelectorExpr(g.MembersOfFunction), newIdent("Controller")), call.Fun)
+	assert.Equal(t, []ast.Expr{newBasicLit("controllers")}, call.Args)
+}
+
+func TestDefineEntryAndInitFunc(t *testing.T) {
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	pkg.Funcs = DiFuncs{
+		{Name: "NewDb", ProviderId: "main.db"},
+		{Name: "NewUserController", GroupId: "controllers"},
+	}
+
+	g := NewGenerator(pkg)
+	g.defineProviderFuncs()
+	g.defineGroupFuncs()
+	g.defineInitCalls()
+	g.defineEntryFunc()
+	g.defineInitFunc()
+	assert.Len(t, g.Decls, 4)
+
+	// Register(c) calls the initialization functions with the container
+	entry := g.Decls[2].(*ast.FuncDecl)
+	assert.Equal(t, "Register", entry.Name.Name)
+	assert.Equal(t, g.containerParams(), entry.Type.Params)
+	assert.Equal(t, []ast.Stmt{
+		&ast.ExprStmt{X: newCallExpr(newIdent("init_main_db"), newExprs(newIdent("c")))},
+		&ast.ExprStmt{X: newCallExpr(newIdent("group_controllers_NewUserController"), newExprs(newIdent("c")))},
+	}, entry.Body.List)
+
+	// init() applies Register(c) to the default container
+	initFunc := g.Decls[3].(*ast.FuncDecl)
+	assert.Equal(t, "init", initFunc.Name.Name)
+	assert.Equal(t, []ast.Stmt{
+		&ast.ExprStmt{X: newCallExpr(newIdent("Register"), newExprs(
+			newCallExpr(newSelectorExpr("digo.Default"), newExprs()),
+		))},
+	}, initFunc.Body.List)
+}
+
+func TestDefineInitCalls_Profile(t *testing.T) {
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	pkg.Funcs = DiFuncs{
+		{Name: "NewFakeMailer", ProviderId: "main.mailer", Profile: "dev", Result: &DiType{Expr: newIdent("Mailer")}},
+		{Name: "NewSmtpMailer", ProviderId: "main.mailer", Profile: "prod", Result: &DiType{Expr: newIdent("Mailer")}},
+	}
+
+	// The providers of a profile are described beforehand, and registered only if the profile is active
+	g := NewGenerator(pkg)
+	g.defineInitCalls()
+	assert.Equal(t, []ast.Stmt{
+		g.defineDescribeStmt(pkg.Funcs[0]),
+		&ast.ExprStmt{X: newCallExpr(newSelectorExpr("c.RegisterProfile"), newExprs(newBasicLit("dev"), newIdent("init_main_mailer_dev")))},
+		g.defineDescribeStmt(pkg.Funcs[1]),
+		&ast.ExprStmt{X: newCallExpr(newSelectorExpr("c.RegisterProfile"), newExprs(newBasicLit("prod"), newIdent("init_main_mailer_prod")))},
+	}, g.CalledInitFuncs)
+	assert.NotContains(t, g.defineProviderFunc(pkg.Funcs[0]).Body.List, g.defineDescribeStmt(pkg.Funcs[0]))
+
+	// Only one getter is generated for the ID
+	g.defineGetterFuncs()
+	assert.Len(t, g.Decls, 1)
+	assert.Equal(t, "ProvideMainMailer", g.Decls[0].(*ast.FuncDecl).Name.Name)
+
+	// No getter is generated if the profiles declare different result types
+	pkg.Funcs[1].Result = &DiType{Expr: &ast.StarExpr{X: newIdent("SmtpMailer")}}
+	g = NewGenerator(pkg)
+	g.defineGetterFuncs()
+	assert.Empty(t, g.Decls)
+}
 
-	// Assert the assignment statement for type assertion.
+func TestDefineGroupFunc_Provider(t *testing.T) {
+	g := NewGenerator(nil)
+	decl := g.defineGroupFunc(&DiFunc{Name: "NewUserController", GroupId: "controllers", ProviderId: "main.user"})
+
+	// A member which is also a provider is retrieved from the container instead of being constructed twice
 	assert.Equal(t, &ast.AssignStmt{
-		Lhs: newExprs(newIdent("myParam")),
+		Lhs: newExprs(newIdent("member"), newIdent("err")),
 		Tok: token.DEFINE,
-		Rhs: newExprs(&ast.TypeAssertExpr{
-			X:    newIdent(objName("myParam")),
-			Type: newIdent("MyType"),
-		}),
-	}, stmts[2])
+		Rhs: newExprs(newCallExpr(newSelectorExpr("c.Provide"), []ast.Expr{newBasicLit("main.user")})),
+	}, decl.Body.List[0])
+	assert.Equal(t, newFailStmt(g.FailMemberFunction, newBasicLit("controllers"), newBasicLit("NewUserController")), decl.Body.List[1])
+}
+
+func TestDefineProviderFunc_Lazy(t *testing.T) {
+	g := NewGenerator(nil)
+	fn := &DiFunc{
+		Name:       "NewDb",
+		ProviderId: "main.db",
+		Lazy:       true,
+		Injectors: []*Injector{{
+			Param:      "url",
+			ProviderId: "main.db.url",
+			Typ:        newIdent("string"),
+		}},
+	}
+
+	decl := g.defineProviderFunc(fn)
+	assert.Len(t, decl.Body.List, 2)
+
+	// The provider is described first
+	assert.Equal(t, g.defineDescribeStmt(fn), decl.Body.List[0])
+
+	// The constructor is wrapped in a factory which is registered as a lazy singleton
+	call := decl.Body.List[1].(*ast.ExprStmt).X.(*ast.CallExpr)
+	assert.Equal(t, newSelectorExpr(g.LazyFunction), call.Fun)
+	assert.Equal(t, newBasicLit("main.db"), call.Args[0])
+
+	factory := call.Args[1].(*ast.FuncLit)
+	assert.Equal(t, g.containerParams(), factory.Type.Params)
+	assert.Len(t, factory.Body.List, 3)
+	assert.Equal(t, newErrReturnStmt(), factory.Body.List[1])
+	assert.Equal(t, &ast.ReturnStmt{
+		Results: newExprs(newCallExpr(newIdent("NewDb"), newExprs(newIdent("url_dep"))), newIdent("nil")),
+	}, factory.Body.List[2])
+}
+
+func TestDefineProviderFunc_Prototype(t *testing.T) {
+	g := NewGenerator(nil)
+	decl := g.defineProviderFunc(&DiFunc{Name: "NewRequest", ProviderId: "main.req", Scope: "prototype"})
+
+	// The constructor is wrapped in a factory which is registered as a prototype
+	call := decl.Body.List[1].(*ast.ExprStmt).X.(*ast.CallExpr)
+	assert.Equal(t, newSelectorExpr(g.PrototypeFunction), call.Fun)
+	assert.Equal(t, newBasicLit("main.req"), call.Args[0])
+	factory := call.Args[1].(*ast.FuncLit)
+	assert.Equal(t, []ast.Stmt{&ast.ReturnStmt{
+		Results: newExprs(newCallExpr(newIdent("NewRequest"), newExprs()), newIdent("nil")),
+	}}, factory.Body.List)
+}
+
+func TestDefineProviderFunc_Fallback(t *testing.T) {
+	g := NewGenerator(nil)
+	decl := g.defineProviderFunc(&DiFunc{Name: "NewMemoryCache", ProviderId: "main.cache", OnMissing: true, Lazy: true})
+
+	// The constructor is wrapped in a factory which is registered as a fallback of its scope
+	call := decl.Body.List[1].(*ast.ExprStmt).X.(*ast.CallExpr)
+	assert.Equal(t, newSelectorExpr(g.FallbackFunction), call.Fun)
+	assert.Equal(t, newExprs(newBasicLit("main.cache"), newBasicLit("singleton")), call.Args[:2])
+	assert.IsType(t, &ast.FuncLit{}, call.Args[2])
+}
+
+func TestDefineProviderFunc_Request(t *testing.T) {
+	g := NewGenerator(nil)
+	fn := &DiFunc{Name: "NewTx", ProviderId: "main.tx", Scope: "request", Result: &DiType{Expr: newStarExpr("sql.Tx")}}
+	decl := g.defineProviderFunc(fn)
+
+	// The constructor is wrapped in a factory which is registered as a request scoped provider
+	call := decl.Body.List[1].(*ast.ExprStmt).X.(*ast.CallExpr)
+	assert.Equal(t, newSelectorExpr(g.ScopedFunction), call.Fun)
+	assert.Equal(t, newBasicLit("main.tx"), call.Args[0])
+	assert.IsType(t, &ast.FuncLit{}, call.Args[1])
+
+	// Request scoped providers have no getter
+	g.Package = &DiPackage{Funcs: DiFuncs{fn}}
+	g.defineGetterFuncs()
+	assert.Empty(t, g.Decls)
+}
+
+func TestDefineProviderFunc_ReturnsErr(t *testing.T) {
+	g := NewGenerator(nil)
+	fn := &DiFunc{Name: "NewDb", ProviderId: "main.db", ReturnsErr: true}
+
+	// The error of the constructor is checked and reported with the provider ID
+	decl := g.defineProviderFunc(fn)
+	assert.Equal(t, []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: newExprs(newIdent("main_db_obj"), newIdent("err")),
+			Tok: token.DEFINE,
+			Rhs: newExprs(newCallExpr(newIdent("NewDb"), newExprs())),
+		},
+		newFailStmt(g.FailFunction, newBasicLit("main.db")),
+		&ast.ExprStmt{
+			X: newCallExpr(newSelectorExpr(g.RegisterFunction), newExprs(newBasicLit("main.db"), newIdent("main_db_obj"))),
+		},
+	}, decl.Body.List[1:])
+
+	// The factory of a lazy provider returns the results of the constructor directly
+	fn.Lazy = true
+	decl = g.defineProviderFunc(fn)
+	factory := decl.Body.List[1].(*ast.ExprStmt).X.(*ast.CallExpr).Args[1].(*ast.FuncLit)
+	assert.Equal(t, []ast.Stmt{&ast.ReturnStmt{
+		Results: newExprs(newCallExpr(newIdent("NewDb"), newExprs())),
+	}}, factory.Body.List)
+
+	// The error of a group member is recorded with the group and the function name
+	decl = g.defineGroupFunc(&DiFunc{Name: "NewUserController", GroupId: "controllers", ReturnsErr: true})
+	assert.Equal(t, newFailStmt(g.FailMemberFunction, newBasicLit("controllers"), newBasicLit("NewUserController")), decl.Body.List[2])
+}
+
+func TestDefineDescribeStmt(t *testing.T) {
+	g := NewGenerator(nil)
+	fn := &DiFunc{
+		Name:       "NewDb",
+		ProviderId: "main.db",
+		GroupId:    "main.closers",
+		Lazy:       true,
+		Result:     &DiType{Expr: &ast.StarExpr{X: newIdent("Db")}},
+		Package:    NewDiPackage("main", "github.com/xxx/app", "/app"),
+		Injectors:  []*Injector{{Param: "url", ProviderId: "main.db.url"}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), g.defineDescribeStmt(fn)))
+	assert.Equal(t, `c.Describe(digo.Metadata{Id: "main.db", Type: "*Db", Constructor: "NewDb", Package: "github.com/xxx/app", `+
+		`Scope: "singleton", Lazy: true, Dependencies: []string{"main.db.url"}, Groups: []string{"main.closers"}})`, buf.String())
+
+	// A group member which is not a provider has no ID nor scope
+	buf.Reset()
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), g.defineDescribeStmt(&DiFunc{Name: "NewUserController", GroupId: "controllers"})))
+	assert.Equal(t, `c.Describe(digo.Metadata{Constructor: "NewUserController", Groups: []string{"controllers"}})`, buf.String())
+}
+
+func TestDefineGroupFunc_Named(t *testing.T) {
+	g := NewGenerator(nil)
+	decl := g.defineGroupFunc(&DiFunc{Name: "NewUserController", GroupId: "controllers", MemberName: "user"})
+
+	// A named member is registered with its name
+	var buf bytes.Buffer
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), decl.Body.List[len(decl.Body.List)-1]))
+	assert.Equal(t, `c.RegisterMemberWith("controllers", member, digo.MemberOptions{Name: "user"})`, buf.String())
+}
+
+func TestDefineMemberOptions(t *testing.T) {
+	g := NewGenerator(nil)
+	fn := &DiFunc{
+		Name:        "NewAuthMiddleware",
+		ProviderId:  "main.auth",
+		GroupId:     "middlewares",
+		MemberOrder: 10,
+		Source:      "github.com/xxx/app/main.go",
+		Line:        12,
+	}
+
+	// The order, the ID and the source position are passed for sorting the members
+	var buf bytes.Buffer
+	lit := &ast.CompositeLit{Type: newSelectorExpr(g.MemberOptionsType), Elts: g.defineMemberOptions(fn)}
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), lit))
+	assert.Equal(t, `digo.MemberOptions{Order: 10, Id: "main.auth", Source: "github.com/xxx/app/main.go", Line: 12}`, buf.String())
+
+	// A member without options is registered anonymously
+	assert.Empty(t, g.defineMemberOptions(&DiFunc{Name: "NewUserController", GroupId: "controllers"}))
+}
+
+func TestDefineInjectStmts_Group(t *testing.T) {
+	g := NewGenerator(nil)
+	inject := &Injector{
+		Param: "ctrls",
+		Group: "controllers",
+		Typ:   &ast.ArrayType{Elt: newIdent("Controller")},
+	}
+
+	// The group is built as a typed slice of its members
+	var buf bytes.Buffer
+	stmts := g.defineInjectStmts(inject, newFailStmt(g.FailFunction, newBasicLit("main.router")))
+	assert.Len(t, stmts, 2)
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), stmts[0]))
+	assert.Equal(t, `ctrls_dep, err := digo.MembersFrom[Controller](c, "controllers")`, buf.String())
+
+	// The injected groups are described apart from the injected providers
+	buf.Reset()
+	fn := &DiFunc{Name: "NewRouter", ProviderId: "main.router", Injectors: []*Injector{{Param: "url", ProviderId: "main.url"}, inject}}
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), g.defineDescribeStmt(fn)))
+	assert.Equal(t, `c.Describe(digo.Metadata{Id: "main.router", Constructor: "NewRouter", Scope: "singleton", `+
+		`Dependencies: []string{"main.url"}, GroupDependencies: []string{"controllers"}})`, buf.String())
+}
+
+func TestDefineInjectStmts_Optional(t *testing.T) {
+	g := NewGenerator(nil)
+	inject := &Injector{
+		Param:      "cache",
+		ProviderId: "main.cache",
+		Optional:   true,
+		Typ:        &ast.StarExpr{X: newIdent("Cache")},
+	}
+
+	// The optional object is provided as the zero value if the provider does not exist
+	var buf bytes.Buffer
+	stmts := g.defineInjectStmts(inject, newErrReturnStmt())
+	assert.Len(t, stmts, 2)
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), stmts[0]))
+	assert.Equal(t, `cache_dep, err := digo.ProvideOptionalFrom[*Cache](c, "main.cache")`, buf.String())
+	assert.Equal(t, newErrReturnStmt(), stmts[1])
+}
+
+func TestDefineInjectStmts_Value(t *testing.T) {
+	g := NewGenerator(nil)
+	def := `"localhost:3306"`
+	inject := &Injector{Param: "url", Key: "db.url", Default: &def, Typ: newIdent("string")}
+
+	// The value is looked up in the configuration source, with the default quoted
+	var buf bytes.Buffer
+	stmts := g.defineInjectStmts(inject, newErrReturnStmt())
+	assert.Len(t, stmts, 2)
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), stmts[0]))
+	assert.Equal(t, `url_dep, err := digo.ValueFrom[string](c, "db.url", "\"localhost:3306\"")`, buf.String())
+
+	buf.Reset()
+	inject = &Injector{Param: "port", Key: "db.port", Typ: newIdent("int")}
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), g.defineInjectStmts(inject, newErrReturnStmt())[0]))
+	assert.Equal(t, `port_dep, err := digo.ValueFrom[int](c, "db.port")`, buf.String())
+
+	// The values are not dependencies
+	buf.Reset()
+	fn := &DiFunc{Name: "NewDb", ProviderId: "main.db", Injectors: []*Injector{inject}}
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), g.defineDescribeStmt(fn)))
+	assert.Equal(t, `c.Describe(digo.Metadata{Id: "main.db", Constructor: "NewDb", Scope: "singleton"})`, buf.String())
+}
+
+func TestDefineProviderFunc_Env(t *testing.T) {
+	g := NewGenerator(nil)
+	def := "localhost"
+	fn := &DiFunc{
+		Name:       "NewServer",
+		ProviderId: "main.server",
+		Injectors: []*Injector{
+			{Param: "port", Env: "APP_PORT", Required: true, Typ: newIdent("int")},
+			{Param: "db", ProviderId: "main.db", Typ: &ast.StarExpr{X: newIdent("Db")}},
+			{Param: "host", Env: "APP_HOST", Default: &def, Typ: newIdent("string")},
+		},
+	}
+
+	// The environment variables are looked up first and their errors are checked together
+	var buf bytes.Buffer
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), g.defineProviderFunc(fn).Body))
+	assert.Contains(t, buf.String(), `	env_vars := digo.NewEnvironment("NewServer")
+	port_dep := digo.LookupEnv[int](env_vars, "APP_PORT", true)
+	host_dep := digo.LookupEnv[string](env_vars, "APP_HOST", false, "localhost")
+	err := env_vars.Err()
+	if err != nil {
+		c.Fail("main.server", err)
+		return
+	}
+	db_dep, err := digo.ProvideFrom[*Db](c, "main.db")`)
+	assert.Contains(t, buf.String(), "main_server_obj := NewServer(port_dep, db_dep, host_dep)")
+}
+
+func TestDefineGroupFunc_ReservedNames(t *testing.T) {
+	g := NewGenerator(nil)
+	fn := &DiFunc{
+		Name:    "NewHandler",
+		GroupId: "handlers",
+		Injectors: []*Injector{
+			{Param: "c", ProviderId: "main.config", Typ: &ast.StarExpr{X: newIdent("Config")}},
+			{Param: "err", ProviderId: "main.err", Typ: newIdent("error")},
+			{Param: "member", Key: "handler.member", Typ: newIdent("string")},
+			{Param: "env_vars", Env: "HANDLER_ENV", Typ: newIdent("string")},
+		},
+	}
+
+	// The injected objects never shadow the container or the variables of the generated code
+	var buf bytes.Buffer
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), g.defineGroupFunc(fn).Body))
+	assert.Contains(t, buf.String(), `	env_vars := digo.NewEnvironment("NewHandler")
+	env_vars_dep := digo.LookupEnv[string](env_vars, "HANDLER_ENV", false)
+	err := env_vars.Err()`)
+	assert.Contains(t, buf.String(), `	c_dep, err := digo.ProvideFrom[*Config](c, "main.config")`)
+	assert.Contains(t, buf.String(), `	err_dep, err := digo.ProvideFrom[error](c, "main.err")`)
+	assert.Contains(t, buf.String(), `	member_dep, err := digo.ValueFrom[string](c, "handler.member")`)
+	assert.Contains(t, buf.String(), `	member := NewHandler(c_dep, err_dep, member_dep, env_vars_dep)
+	c.RegisterMember("handlers", member)`)
+}
+
+func TestDefineFactoryLit_Config(t *testing.T) {
+	g := NewGenerator(nil)
+
+	// A struct type is bound to a new object
+	fn := &DiFunc{Name: "ServerConfig", ProviderId: "config.server", ConfigPrefix: "server", IsType: true}
+	var buf bytes.Buffer
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), g.defineFactoryLit(fn).Body))
+	assert.Contains(t, buf.String(), `return digo.BindConfig(c, "server", &ServerConfig{})`)
 
+	// The object created by the provider is used as the base of the binding
+	fn = &DiFunc{
+		Name:         "NewDbConfig",
+		ProviderId:   "main.dbconfig",
+		ConfigPrefix: "database",
+		ReturnsErr:   true,
+		Injectors:    []*Injector{{Param: "url", Key: "db.url", Typ: newIdent("string")}},
+	}
+	buf.Reset()
+	assert.NoError(t, format.Node(&buf, token.NewFileSet(), g.defineFactoryLit(fn).Body))
+	assert.Contains(t, buf.String(), `	main_dbconfig_obj, err := NewDbConfig(url_dep)
+	if err != nil {
+		return nil, err
+	}
+	return digo.BindConfig(c, "database", main_dbconfig_obj)`)
+}
+
+// generateGolden generates the code of the package in the folder dir by a parser of its own,
+// and compares it with the golden file digo.generated.go of the folder.
+func generateGolden(t *testing.T, dir string) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}, ".")
+	require.NoError(t, err)
+
+	parser := NewParser()
+	require.NoError(t, parser.parse(pkgs))
+	require.True(t, parser.check())
+	require.Len(t, parser.Packages, 1)
+
+	// The code is generated into a temporary folder and compared with the golden file
+	pkg := parser.Packages[0]
+	pkg.Folder = t.TempDir()
+	NewGenerator(pkg).Do()
+	generated, err := os.ReadFile(filepath.Join(pkg.Folder, "digo.generated.go"))
+	require.NoError(t, err)
+
+	golden := filepath.Join(dir, "digo.generated.go")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, generated, 0644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(generated), "run go test -run TestGenerator -update to update the golden files")
+}
+
+func TestGenerator_Golden(t *testing.T) {
+	dir := filepath.Join("testdata", "golden")
+	generateGolden(t, dir)
+
+	// The golden file compiles together with the annotated source
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	out, err := exec.Command("go", "vet", "./"+filepath.ToSlash(dir)).CombinedOutput()
+	assert.NoError(t, err, string(out))
+}
+
+func TestGenerator_Fallback(t *testing.T) {
+	// The library and the application are generated separately, so the fallback of the library is generated too
+	generateGolden(t, filepath.Join("testdata", "fallback", "lib"))
+	generateGolden(t, filepath.Join("testdata", "fallback", "app"))
+
+	// The fallback registered by the library yields to the provider of the application initialized after it,
+	// and the singleton of the library depending on it is created with the provider of the application
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	out, err := exec.Command("go", "run", "./testdata/fallback/app").CombinedOutput()
+	assert.NoError(t, err, string(out))
+	assert.Equal(t, "redis cache\n", string(out))
 }
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MemberOptions are the options of a group member.
+// The members of a group are sorted by their orders, then by their IDs, then by their source positions,
+// the members with the same options keep the order of their registration.
+// MemberOptions 是组成员的选项，组的成员按照顺序、ID、源码位置依次排序，选项相同的成员保持注册的顺序
+type MemberOptions struct {
+	Name   string // Name is the unique name of the member in the group, e.g. its role, empty for an anonymous member.
+	Order  int    // Order is the explicit order of the member, the members with lower orders come first.
+	Id     string // Id is the provider ID of the member if it is also a provider, otherwise its name is used as its ID.
+	Source string // Source is the file declaring the constructor of the member, i.e. the package path followed by the file name.
+	Line   int    // Line is the line of the constructor in the source file.
+}
+
+// id returns the ID used to sort the member.
+func (opts *MemberOptions) id() string {
+	if len(opts.Id) > 0 {
+		return opts.Id
+	}
+	return opts.Name
+}
+
+// less reports whether the member with the options should come before the member with the other options.
+// less 判断使用该选项的成员是否应该排在使用other选项的成员之前
+func (opts *MemberOptions) less(other *MemberOptions) bool {
+	if opts.Order != other.Order {
+		return opts.Order < other.Order
+	}
+	if opts.id() != other.id() {
+		return opts.id() < other.id()
+	}
+	if opts.Source != other.Source {
+		return opts.Source < other.Source
+	}
+	return opts.Line < other.Line
+}
+
+// member represents an object registered into a group.
+// member 表示注册到组中的一个对象
+type member struct {
+	opts   MemberOptions
+	object any
+}
+
+// RegisterMemberWith registers a member object with the provided group ID and options.
+// A named member can be looked up by its name through MemberMap, and the name must be unique in the group,
+// a duplicate name is returned as an error wrapping ErrDuplicateMember, and it is recorded so that Init reports it.
+// RegisterMemberWith 使用指定的选项将对象注册到组中，有名字的成员可以通过MemberMap根据名字查找，
+// 名字在组中必须是唯一的，重复的名字会返回一个包装了ErrDuplicateMember的错误，该错误同样会被记录下来由Init报告
+func (c *Container) RegisterMemberWith(groupId string, object any, opts MemberOptions) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(opts.Name) > 0 {
+		for _, m := range c.groups[groupId] {
+			if m.opts.Name == opts.Name {
+				err := fmt.Errorf("%w %s in group %s", ErrDuplicateMember, opts.Name, groupId)
+				c.errs = append(c.errs, err)
+				return err
+			}
+		}
+	}
+
+	// Insert the member after the members which do not come after it, so that the group stays sorted.
+	// 将成员插入到不排在它之后的成员后面，以保持组的有序
+	group := c.groups[groupId]
+	i := len(group)
+	for i > 0 && opts.less(&group[i-1].opts) {
+		i--
+	}
+	group = append(group, nil)
+	copy(group[i+1:], group[i:])
+	group[i] = &member{opts: opts, object: object}
+	c.groups[groupId] = group
+	c.created = append(c.created, &entry{id: groupId, member: true, object: object})
+	return nil
+}
+
+// group returns a copy of the members of the group with the ID.
+// A child scope returns the group of its parent if the group is not registered into the scope itself.
+// It returns an error if the group does not exist or any of its members failed to be initialized.
+// group 返回指定组的成员的副本，如果子作用域中没有注册该组，则返回父容器中的组
+func (c *Container) group(name string) ([]*member, error) {
+	for s := c; s != nil; s = s.parent {
+		s.mu.RLock()
+		group, ok := s.groups[name]
+		members := make([]*member, len(group))
+		copy(members, group)
+		broken := s.broken[name]
+		s.mu.RUnlock()
+		if len(broken) > 0 {
+			return nil, errors.Join(broken...)
+		}
+		if ok {
+			return members, nil
+		}
+	}
+	return nil, &NotFoundError{Id: name, Suggestions: suggest(name, c.groupIds()), Err: ErrGroupNotFound}
+}
+
+// MemberMap returns the named members of the group associated with the provided group ID, keyed by their names.
+// The anonymous members are not included. It returns an error if the group does not exist.
+// MemberMap 返回指定组中有名字的成员，以名字为键，匿名成员不包含在内。如果组不存在则返回错误
+func (c *Container) MemberMap(groupId string) (map[string]any, error) {
+	group, err := c.group(groupId)
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string]any)
+	for _, m := range group {
+		if len(m.opts.Name) > 0 {
+			members[m.opts.Name] = m.object
+		}
+	}
+	return members, nil
+}
+
+// RegisterMemberWith registers a member object with the provided group ID and options into the default container.
+func RegisterMemberWith(groupId string, object any, opts MemberOptions) error {
+	return defaultContainer.RegisterMemberWith(groupId, object, opts)
+}
+
+// MemberMap returns the named members of the group associated with the provided group ID from the default container.
+func MemberMap(groupId string) (map[string]any, error) {
+	return defaultContainer.MemberMap(groupId)
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_MemberMap(t *testing.T) {
+	c := NewContainer()
+	assert.NoError(t, c.RegisterMemberWith("keyed.controllers", "user controller", MemberOptions{Name: "user"}))
+	assert.NoError(t, c.RegisterMemberWith("keyed.controllers", "role controller", MemberOptions{Name: "role"}))
+	c.RegisterMember("keyed.controllers", "anonymous controller")
+
+	// Named members are looked up by their names, anonymous members are only listed by Members
+	members, err := c.MemberMap("keyed.controllers")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"user": "user controller", "role": "role controller"}, members)
+
+	all, err := c.NewScope().Members("keyed.controllers")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"anonymous controller", "role controller", "user controller"}, all)
+
+	// A duplicate name is an error, and the first member is kept
+	err = c.RegisterMemberWith("keyed.controllers", "another user controller", MemberOptions{Name: "user"})
+	assert.EqualError(t, err, "duplicate member user in group keyed.controllers")
+	assert.ErrorIs(t, err, ErrDuplicateMember)
+	assert.ErrorIs(t, c.Init(), ErrDuplicateMember)
+
+	members, err = c.MemberMap("keyed.controllers")
+	assert.NoError(t, err)
+	assert.Equal(t, "user controller", members["user"])
+
+	_, err = MemberMap("keyed.nonexistent")
+	assert.ErrorIs(t, err, ErrGroupNotFound)
+}
+
+func TestContainer_MembersOrder(t *testing.T) {
+	c := NewContainer()
+	c.RegisterMemberWith("ordered.middlewares", "recover", MemberOptions{Order: -10, Source: "github.com/xxx/b/b.go", Line: 3})
+	c.RegisterMemberWith("ordered.middlewares", "logger 2", MemberOptions{Source: "github.com/xxx/a/a.go", Line: 20})
+	c.RegisterMemberWith("ordered.middlewares", "auth", MemberOptions{Order: 10, Source: "github.com/xxx/a/a.go", Line: 1})
+	c.RegisterMemberWith("ordered.middlewares", "logger 1", MemberOptions{Source: "github.com/xxx/a/a.go", Line: 9})
+	c.RegisterMemberWith("ordered.middlewares", "cors", MemberOptions{Id: "main.cors", Source: "github.com/xxx/c/c.go", Line: 1})
+	c.RegisterMemberWith("ordered.middlewares", "logger 3", MemberOptions{Source: "github.com/xxx/a/a.go", Line: 20})
+
+	// Members are sorted by order, then by ID, then by source position, regardless of the registration order
+	members, err := c.Members("ordered.middlewares")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"recover", "logger 1", "logger 2", "logger 3", "cors", "auth"}, members)
+}
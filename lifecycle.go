@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// contextCloser is implemented by the objects which need a context to be closed.
+// contextCloser 表示关闭时需要context的对象
+type contextCloser interface {
+	Close(ctx context.Context) error
+}
+
+// Starter is implemented by the long-running components which need to be started after the object graph is built,
+// e.g. servers and consumers.
+// Starter 表示需要在对象图构建完成之后启动的长时间运行的组件，比如服务器和消费者
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper is implemented by the started components which need to be stopped, e.g. to shut down a server gracefully.
+// Stopper 表示启动后需要被停止的组件，比如优雅地关闭服务器
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// RegisterDependencies records that the provider with the provided ID depends on the providers with the IDs deps,
+// so that Start starts the objects after their dependencies and Close disposes them before their dependencies.
+// The generated code records the dependencies through Describe, RegisterDependencies is meant for the providers
+// registered by hand, whose dependencies are unknown to the container otherwise.
+// RegisterDependencies 记录指定ID的provider依赖于deps中的provider，以便Start在依赖之后启动对象，Close在依赖之前释放对象。
+// 生成的代码通过Describe记录依赖，RegisterDependencies用于手动注册的provider，否则容器无法知道它们的依赖
+func (c *Container) RegisterDependencies(id string, deps ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deps[id] = append(c.deps[id], deps...)
+}
+
+// dependencies returns the IDs of the providers that the provider with the ID depends on,
+// which are recorded in the container and its parents.
+// dependencies 返回容器及其父容器中记录的指定provider的依赖
+func (c *Container) dependencies(id string) []string {
+	var deps []string
+	for s := c; s != nil; s = s.parent {
+		s.mu.RLock()
+		deps = append(deps, s.deps[id]...)
+		s.mu.RUnlock()
+	}
+	return deps
+}
+
+// groupDependencies returns the IDs of the groups that the provider with the ID depends on,
+// which are recorded in the container and its parents.
+// groupDependencies 返回容器及其父容器中记录的指定provider所依赖的组
+func (c *Container) groupDependencies(id string) []string {
+	var groups []string
+	for s := c; s != nil; s = s.parent {
+		s.mu.RLock()
+		groups = append(groups, s.groupDeps[id]...)
+		s.mu.RUnlock()
+	}
+	return groups
+}
+
+// closeOrder sorts the created objects in the order in which they are disposed: an object is disposed before
+// the objects it depends on, and the objects without dependencies between them are disposed in the reverse order
+// of their creation.
+// closeOrder 返回对象的释放顺序：对象在它依赖的对象之前释放，没有依赖关系的对象按照创建顺序的逆序释放
+func (c *Container) closeOrder(created []*entry) []*entry {
+	providers := make(map[string]*entry)
+	members := make(map[string][]*entry)
+	for _, p := range created {
+		if p.member {
+			members[p.id] = append(members[p.id], p)
+		} else {
+			providers[p.id] = p
+		}
+	}
+
+	// The post order of the dependency graph lists the dependencies before their dependents.
+	// 依赖图的后序遍历会将依赖排在依赖它的对象之前
+	order := make([]*entry, 0, len(created))
+	visited := make(map[*entry]bool)
+	var visit func(p *entry)
+	visit = func(p *entry) {
+		if visited[p] {
+			return
+		}
+		visited[p] = true
+		if !p.member {
+			for _, dep := range c.dependencies(p.id) {
+				if d, ok := providers[dep]; ok {
+					visit(d)
+				}
+			}
+			// A provider which is injected with a group depends on all members of the group.
+			// 注入了组的provider依赖于组的所有成员
+			for _, group := range c.groupDependencies(p.id) {
+				for _, m := range members[group] {
+					visit(m)
+				}
+			}
+		}
+		order = append(order, p)
+	}
+	for _, p := range created {
+		visit(p)
+	}
+
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+// distinct removes the providers without an object and the providers whose object is already listed,
+// since the same object may be registered as a provider and as a group member.
+// distinct 移除没有对象的provider以及对象重复的provider，因为同一个对象可能同时被注册为provider和组成员
+func distinct(entries []*entry) []*entry {
+	result := make([]*entry, 0, len(entries))
+	seen := make(map[any]bool)
+	for _, p := range entries {
+		if p.object == nil {
+			continue
+		}
+		if reflect.ValueOf(p.object).Kind() == reflect.Pointer {
+			if seen[p.object] {
+				continue
+			}
+			seen[p.object] = true
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// closeObject disposes the object if it implements io.Closer or Close(ctx) error.
+// closeObject 如果对象实现了io.Closer或者Close(ctx) error，则关闭该对象
+func closeObject(ctx context.Context, object any) error {
+	switch closer := object.(type) {
+	case contextCloser:
+		return closer.Close(ctx)
+	case io.Closer:
+		return closer.Close()
+	}
+	return nil
+}
+
+// Close closes the container and disposes the objects created by it, including the singletons, the lazy singletons
+// and the group members. An object is disposed before the objects it depends on, and the objects without dependencies
+// between them are disposed in the reverse order of their creation.
+// The objects implementing io.Closer or Close(ctx) error are closed, each object only once even if it is
+// registered several times, and all the errors are joined together.
+// The parent of a scope is not affected by closing the scope.
+// Close 关闭容器，并释放容器创建的对象，包括单例、延迟创建的单例以及组成员。对象会在它依赖的对象之前释放，
+// 没有依赖关系的对象按照创建顺序的逆序释放。实现了io.Closer或者Close(ctx) error的对象会被关闭，
+// 即使对象被注册了多次也只会关闭一次，所有的错误会被合并返回。关闭子作用域不会影响它的父容器
+func (c *Container) Close(ctx context.Context) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	created := c.created
+	c.created = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for _, p := range distinct(c.closeOrder(created)) {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := closeObject(ctx, p.object); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s: %w", p.id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start starts the objects created by the container which implement Starter, after the whole object graph is built.
+// An object is started after the objects it depends on, and the objects without dependencies between them are
+// started in the order of their creation. Each Start call is given a context with the timeout, no timeout if the
+// timeout is zero. If an object fails to start, the objects already started are stopped in the reverse order,
+// and the errors are returned. The lazy singletons which have not been created yet are not started.
+// Start 启动容器创建的实现了Starter的对象，应该在整个对象图构建完成后调用。对象会在它依赖的对象之后启动，
+// 没有依赖关系的对象按照创建顺序启动。每次调用Start都会传入一个带有超时时间的context，timeout为0表示不超时。
+// 如果有对象启动失败，已经启动的对象会按照逆序被停止，并返回错误。还没有被创建的延迟单例不会被启动
+func (c *Container) Start(ctx context.Context, timeout time.Duration) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return errors.New("container is closed")
+	}
+	if c.starting {
+		c.mu.Unlock()
+		return errors.New("container is already started")
+	}
+	c.starting = true
+	created := make([]*entry, len(c.created))
+	copy(created, c.created)
+	c.mu.Unlock()
+
+	order := distinct(c.closeOrder(created))
+	started := make([]*entry, 0)
+	for i := len(order) - 1; i >= 0; i-- {
+		p := order[i]
+		starter, ok := p.object.(Starter)
+		if !ok {
+			continue
+		}
+		if err := startObject(ctx, starter, timeout); err != nil {
+			errs := []error{fmt.Errorf("failed to start %s: %w", p.id, err)}
+			errs = append(errs, stopObjects(ctx, started)...)
+			c.mu.Lock()
+			c.starting = false
+			c.mu.Unlock()
+			return errors.Join(errs...)
+		}
+		started = append(started, p)
+	}
+
+	c.mu.Lock()
+	c.started = started
+	c.mu.Unlock()
+	return nil
+}
+
+// startObject starts the object with a context derived from ctx with the timeout.
+func startObject(ctx context.Context, starter Starter, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return starter.Start(ctx)
+}
+
+// stopObjects stops the started objects which implement Stopper in the reverse order of their starting.
+func stopObjects(ctx context.Context, started []*entry) []error {
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		if stopper, ok := started[i].object.(Stopper); ok {
+			if err := stopper.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to stop %s: %w", started[i].id, err))
+			}
+		}
+	}
+	return errs
+}
+
+// Stop stops the objects started by Start which implement Stopper, in the reverse order of their starting,
+// and all the errors are joined together. The container can be started again after it is stopped.
+// Stop 按照启动顺序的逆序停止通过Start启动的实现了Stopper的对象，所有的错误会被合并返回，停止后容器可以再次启动
+func (c *Container) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	started := c.started
+	c.started = nil
+	c.starting = false
+	c.mu.Unlock()
+	return errors.Join(stopObjects(ctx, started)...)
+}
+
+// RegisterDependencies records the dependencies of the provider registered by hand with the provided ID into the default container.
+func RegisterDependencies(id string, deps ...string) {
+	defaultContainer.RegisterDependencies(id, deps...)
+}
+
+// Close closes the default container and disposes the objects created by it.
+// It is meant to be called when the application exits, e.g. `defer digo.Close(ctx)` in main.
+// Close 关闭默认容器并释放它创建的对象，建议在应用退出时调用，比如在main中`defer digo.Close(ctx)`
+func Close(ctx context.Context) error {
+	return defaultContainer.Close(ctx)
+}
+
+// Start starts the objects created by the default container which implement Starter,
+// each with a context with the timeout.
+func Start(ctx context.Context, timeout time.Duration) error {
+	return defaultContainer.Start(ctx, timeout)
+}
+
+// Stop stops the objects started by Start in the default container.
+func Stop(ctx context.Context) error {
+	return defaultContainer.Stop(ctx)
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConn struct {
+	name   string
+	closed *[]string
+}
+
+func (conn *testConn) Close(ctx context.Context) error {
+	*conn.closed = append(*conn.closed, conn.name)
+	return ctx.Err()
+}
+
+func TestContainer_Close(t *testing.T) {
+	c := NewContainer()
+	closed := make([]string, 0)
+
+	// The app is registered before the db it depends on, e.g. by hand
+	app := &testTx{name: "app", closed: &closed}
+	c.RegisterSingleton("close.app", app)
+	c.RegisterDependencies("close.app", "close.db")
+	c.RegisterSingleton("close.db", &testConn{name: "db", closed: &closed})
+	c.RegisterLazy("close.cache", func(c *Container) (any, error) {
+		return &testTx{name: "broken", closed: &closed}, nil
+	})
+	c.RegisterMember("close.group", app)
+	c.RegisterMember("close.group", "not a closer")
+
+	_, err := c.Provide("close.cache")
+	assert.NoError(t, err)
+
+	// Objects are closed before their dependencies, each object only once, and the errors are returned
+	err = c.Close(context.Background())
+	assert.EqualError(t, err, "failed to close close.cache: broken pipe")
+	assert.Equal(t, []string{"broken", "app", "db"}, closed)
+
+	_, err = c.Provide("close.db")
+	assert.EqualError(t, err, "container is closed")
+	assert.NoError(t, c.Close(context.Background()))
+}
+
+func TestContainer_CloseGroupDependencies(t *testing.T) {
+	c := NewContainer()
+	closed := make([]string, 0)
+
+	// The router is created before the handlers of the group injected into it
+	c.Describe(Metadata{Id: "close.router", GroupDependencies: []string{"close.handlers"}})
+	c.RegisterSingleton("close.router", &testConn{name: "router", closed: &closed})
+	c.RegisterMember("close.handlers", &testConn{name: "user", closed: &closed})
+	c.RegisterMember("close.handlers", &testConn{name: "role", closed: &closed})
+
+	// The router is closed before all members of the group
+	assert.NoError(t, c.Close(context.Background()))
+	assert.Equal(t, []string{"router", "role", "user"}, closed)
+}
+
+func TestContainer_CloseContext(t *testing.T) {
+	c := NewContainer()
+	closed := make([]string, 0)
+	c.RegisterSingleton("close.db", &testConn{name: "db", closed: &closed})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A cancelled context stops disposing the remaining objects
+	err := c.Close(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Empty(t, closed)
+}
+
+type testServer struct {
+	name   string
+	events *[]string
+	err    error
+}
+
+func (s *testServer) Start(ctx context.Context) error {
+	if s.err != nil {
+		return s.err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("no deadline")
+	}
+	*s.events = append(*s.events, "start "+s.name)
+	return nil
+}
+
+func (s *testServer) Stop(ctx context.Context) error {
+	*s.events = append(*s.events, "stop "+s.name)
+	return nil
+}
+
+func TestContainer_Start(t *testing.T) {
+	c := NewContainer()
+	events := make([]string, 0)
+
+	// The api depends on the consumer, which is registered later
+	c.RegisterSingleton("start.api", &testServer{name: "api", events: &events})
+	c.RegisterDependencies("start.api", "start.consumer")
+	c.RegisterSingleton("start.consumer", &testServer{name: "consumer", events: &events})
+	c.RegisterSingleton("start.name", "not a starter")
+
+	// Components are started after their dependencies, each with the timeout
+	assert.NoError(t, c.Start(context.Background(), time.Second))
+	assert.Equal(t, []string{"start consumer", "start api"}, events)
+	assert.EqualError(t, c.Start(context.Background(), time.Second), "container is already started")
+
+	// Components are stopped in the reverse order
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.Equal(t, []string{"start consumer", "start api", "stop api", "stop consumer"}, events)
+}
+
+func TestContainer_StartFailed(t *testing.T) {
+	c := NewContainer()
+	events := make([]string, 0)
+
+	c.RegisterSingleton("start.db", &testServer{name: "db", events: &events})
+	c.RegisterSingleton("start.cache", &testServer{name: "cache", events: &events})
+	c.RegisterSingleton("start.api", &testServer{name: "api", events: &events, err: errors.New("address in use")})
+
+	// The components already started are stopped again if a component fails to start
+	err := c.Start(context.Background(), time.Second)
+	assert.EqualError(t, err, "failed to start start.api: address in use")
+	assert.Equal(t, []string{"start db", "start cache", "stop cache", "stop db"}, events)
+
+	// Nothing is left to stop
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.Len(t, events, 4)
+}
+
+func TestRegisterDependencies(t *testing.T) {
+	// The dependencies of the providers registered by hand are recorded together with the described ones
+	c := NewContainer()
+	c.Describe(Metadata{Id: "deps.app", Dependencies: []string{"deps.db"}})
+	c.RegisterDependencies("deps.app", "deps.cache")
+	assert.Equal(t, []string{"deps.db", "deps.cache"}, c.dependencies("deps.app"))
+	assert.Equal(t, []string{"deps.db", "deps.cache"}, c.NewScope().dependencies("deps.app"))
+
+	RegisterDependencies("deps.default", "deps.db")
+	assert.Equal(t, []string{"deps.db"}, Default().dependencies("deps.default"))
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Metadata describes a provider or a group member registered into a container.
+// The generated code describes every provider and group member with the information known by digogen,
+// the providers registered by hand are described with the information known at runtime.
+// Metadata 描述了注册到容器中的一个provider或者组成员，生成的代码会使用digogen解析到的信息描述每个provider和组成员，
+// 手动注册的provider则使用运行时能获取到的信息进行描述
+type Metadata struct {
+	Id                string   `json:"id,omitempty"`                // Id is the provider ID, empty for a group member which is not a provider.
+	Type              string   `json:"type,omitempty"`              // Type is the declared result type of the constructor.
+	ConcreteType      string   `json:"concreteType,omitempty"`      // ConcreteType is the dynamic type of the created object, empty if it is not created yet.
+	Constructor       string   `json:"constructor,omitempty"`       // Constructor is the name of the constructor function.
+	Package           string   `json:"package,omitempty"`           // Package is the import path of the package which registers the provider.
+	Scope             Scope    `json:"scope,omitempty"`             // Scope is the scope of the provider.
+	Lazy              bool     `json:"lazy,omitempty"`              // Lazy indicates that the object is created on the first time it is provided.
+	Dependencies      []string `json:"dependencies,omitempty"`      // Dependencies are the IDs of the providers injected into the constructor.
+	Groups            []string `json:"groups,omitempty"`            // Groups are the IDs of the groups which the object is a member of.
+	GroupDependencies []string `json:"groupDependencies,omitempty"` // GroupDependencies are the IDs of the groups injected into the constructor.
+	Name              string   `json:"name,omitempty"`              // Name is the name of the object in its groups, empty for an anonymous member.
+	Profile           string   `json:"profile,omitempty"`           // Profile is the profile which the provider belongs to, empty if it belongs to every profile.
+	Fallback          bool     `json:"fallback,omitempty"`          // Fallback indicates that the provider yields to any other provider of the same ID.
+}
+
+// Describe records the metadata of a provider or a group member. The dependencies of a provider, including the groups
+// injected into it, are recorded as well, so that the objects are disposed in the reverse order of their dependencies.
+// The provider of a profile may be described before the profile is activated, its dependencies are recorded
+// only if the profile is activated.
+// Describe 记录一个provider或者组成员的元数据，provider的依赖也会被记录下来，以便按照依赖关系的逆序释放对象。
+// profile的provider可以在profile激活之前描述，只有该profile被激活时才会记录它的依赖
+func (c *Container) Describe(meta Metadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metas = append(c.metas, meta)
+	if c.described(meta) {
+		c.dependOn(meta)
+	}
+}
+
+// described returns whether the metadata belongs to the registered providers, i.e. it has no profile,
+// or its profile is activated. The caller must hold the lock.
+// described 返回元数据是否属于已经注册的provider，即没有profile或者其profile已经激活，调用者必须持有锁
+func (c *Container) described(meta Metadata) bool {
+	return len(meta.Profile) == 0 || (c.activated && meta.Profile == c.profile)
+}
+
+// dependOn records the dependencies of the provider described by the metadata. The caller must hold the lock.
+// dependOn 记录元数据描述的provider的依赖，调用者必须持有锁
+func (c *Container) dependOn(meta Metadata) {
+	if len(meta.Id) > 0 && len(meta.Dependencies) > 0 {
+		c.deps[meta.Id] = append(c.deps[meta.Id], meta.Dependencies...)
+	}
+	if len(meta.Id) > 0 && len(meta.GroupDependencies) > 0 {
+		c.groupDeps[meta.Id] = append(c.groupDeps[meta.Id], meta.GroupDependencies...)
+	}
+}
+
+// Registered returns the metadata of the providers and the group members registered into the container,
+// the described ones in the order they were described, followed by the providers registered by hand ordered by ID.
+// The concrete type is filled in for the objects already created, and a fallback which yields to another provider is left out.
+// Only the providers of the active profile are returned,
+// and the profile is not activated by Registered, the providers of a profile which is not activated yet are returned by Pending.
+// Registered 返回注册到容器中的provider和组成员的元数据，先按描述的顺序返回生成的代码描述过的元数据，
+// 再按ID的顺序返回手动注册的provider，已经创建的对象会填充它的实际类型，让位于其他provider的后备provider不会被返回。
+// 只返回当前激活的profile的provider，
+// Registered不会激活profile，尚未激活的profile的provider由Pending返回
+func (c *Container) Registered() []Metadata {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	described := make(map[string]bool)
+	metas := make([]Metadata, 0, len(c.metas)+len(c.providers))
+	for _, meta := range c.metas {
+		if !c.described(meta) {
+			continue
+		}
+
+		// A fallback which yields to another provider is not registered.
+		// 让位于其他provider的后备provider没有被注册
+		if p, ok := c.providers[meta.Id]; meta.Fallback && ok && !p.fallback {
+			continue
+		}
+		if len(meta.Id) > 0 {
+			if described[meta.Id] {
+				continue
+			}
+			described[meta.Id] = true
+		}
+		metas = append(metas, meta)
+	}
+
+	ids := make([]string, 0)
+	for id := range c.providers {
+		if !described[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		p := c.providers[id]
+		metas = append(metas, Metadata{
+			Id:           id,
+			Package:      p.pkg,
+			Scope:        p.scope,
+			Lazy:         p.factory != nil && p.scope == ScopeSingleton,
+			Dependencies: c.deps[id],
+		})
+	}
+
+	for i := range metas {
+		if p, ok := c.providers[metas[i].Id]; ok && len(metas[i].Id) > 0 {
+			if object := p.created(); object != nil {
+				metas[i].ConcreteType = reflect.TypeOf(object).String()
+			}
+		}
+	}
+	return metas
+}
+
+// Pending returns the metadata of the providers of all profiles described before the profile is activated,
+// which are registered when the profile is activated. It returns nothing once the profile is activated.
+// Pending 返回在profile激活之前描述的所有profile的provider的元数据，它们会在profile激活的时候注册，
+// profile激活之后不再返回任何元数据
+func (c *Container) Pending() []Metadata {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	metas := make([]Metadata, 0)
+	if c.activated {
+		return metas
+	}
+	for _, meta := range c.metas {
+		if len(meta.Profile) > 0 {
+			metas = append(metas, meta)
+		}
+	}
+	return metas
+}
+
+// Registered returns the metadata of the providers and the group members registered into the default container.
+func Registered() []Metadata {
+	return defaultContainer.Registered()
+}
+
+// Pending returns the metadata of the providers of the profiles which are not activated yet in the default container.
+func Pending() []Metadata {
+	return defaultContainer.Pending()
+}
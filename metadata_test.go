@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Registered(t *testing.T) {
+	c := NewContainer()
+
+	c.Describe(Metadata{Id: "meta.db", Type: "*Db", Constructor: "NewDb", Package: "github.com/xxx/app",
+		Scope: ScopeSingleton, Lazy: true, Dependencies: []string{"meta.url"}})
+	c.RegisterLazy("meta.db", func(c *Container) (any, error) {
+		return &testTx{db: "db"}, nil
+	})
+	c.Describe(Metadata{Constructor: "NewController", Package: "github.com/xxx/app", Groups: []string{"meta.controllers"}})
+	c.RegisterMember("meta.controllers", "controller")
+	c.RegisterSingleton("meta.url", "localhost:3306")
+
+	// The described metadata come first, followed by the providers registered by hand
+	metas := c.Registered()
+	assert.Equal(t, []Metadata{
+		{Id: "meta.db", Type: "*Db", Constructor: "NewDb", Package: "github.com/xxx/app",
+			Scope: ScopeSingleton, Lazy: true, Dependencies: []string{"meta.url"}},
+		{Constructor: "NewController", Package: "github.com/xxx/app", Groups: []string{"meta.controllers"}},
+		{Id: "meta.url", ConcreteType: "string", Package: "github.com/werbenhu/digo", Scope: ScopeSingleton},
+	}, metas)
+
+	// The concrete type is known once the object is created
+	_, err := c.Provide("meta.db")
+	assert.NoError(t, err)
+	assert.Equal(t, "*digo.testTx", c.Registered()[0].ConcreteType)
+
+	// The described dependencies are used for disposing the objects in order
+	assert.Equal(t, []string{"meta.url"}, c.dependencies("meta.db"))
+
+	// A fallback is listed until another provider of the ID is registered
+	c.Describe(Metadata{Id: "meta.cache", Constructor: "NewMemoryCache", Scope: ScopeSingleton, Lazy: true, Fallback: true})
+	c.RegisterFallback("meta.cache", ScopeSingleton, func(c *Container) (any, error) {
+		return "memory cache", nil
+	})
+	assert.Equal(t, "NewMemoryCache", c.Registered()[2].Constructor)
+	c.Describe(Metadata{Id: "meta.cache", Constructor: "NewRedisCache", Scope: ScopeSingleton})
+	c.RegisterSingleton("meta.cache", "redis cache")
+	assert.Equal(t, "NewRedisCache", c.Registered()[2].Constructor)
+	assert.Len(t, c.Registered(), 4)
+}
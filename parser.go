@@ -9,19 +9,23 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	goparser "go/parser"
 	"go/token"
+	"go/types"
 	"log"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"unicode"
 
 	"golang.org/x/tools/go/packages"
 )
 
 const (
 	// RegexpText represents the regular expression pattern for parsing annotations.
-	RegexpText = `^//\s*@(provider|inject|group)\s*\((.*)\s*\)`
+	RegexpText = `^//\s*@(provider|inject|group|value|env|config)\s*\((.*)\s*\)`
 )
 
 // chain represents the dependency chain of a provider and is used to determine whether there is a cyclic dependency.
@@ -50,18 +54,18 @@ func (c chain) String() string {
 	var p string
 	separator := " -> "
 	for _, fn := range c {
-		p = p + fn.ProviderId + separator
+		p = p + fn.chainName() + separator
 	}
 	return p[:len(p)-len(separator)]
 }
 
-// insert inserts a new provider into the current dependency chain.
-// If the provider is already present in the dependency chain, indicating a cyclic dependency, it returns false.
-// insert 往当前依赖链中插入一个新来的provider
-// 如果发现当前依赖链中已经存在该provider，则表明有循环依赖，返回false
+// insert inserts a new provider or group member into the current dependency chain.
+// If the function is already present in the dependency chain, indicating a cyclic dependency, it returns false.
+// insert 往当前依赖链中插入一个新来的provider或者组成员
+// 如果发现当前依赖链中已经存在该函数，则表明有循环依赖，返回false
 func (c *chain) insert(fn *DiFunc) bool {
 	for _, f := range *c {
-		if f.ProviderId == fn.ProviderId {
+		if f == fn {
 			*c = append(*c, fn)
 			return false
 		}
@@ -72,23 +76,82 @@ func (c *chain) insert(fn *DiFunc) bool {
 
 // Provider represents a provider.
 type Provider struct {
-	Id string // Id represents the identifier of the provider.
+	Id    string // Id represents the identifier of the provider.
+	Lazy  bool   // Lazy indicates that the object is created on the first time it is provided instead of in init().
+	Scope string // Scope represents the scope of the provider's objects, "singleton" by default, "prototype" or "request".
+
+	// Profile represents the profile which the provider belongs to, such as "dev", the provider is registered
+	// only if the profile is active. A provider without a profile belongs to every profile.
+	Profile string
+
+	// OnMissing indicates that the provider is a fallback, which is registered only if no other provider has the same ID.
+	OnMissing bool
+	// ConditionalOn represents the ID of another provider, the provider is registered only if that provider exists.
+	ConditionalOn string
 }
 
 // Member represents a member in a group.
 type Member struct {
-	GroupId string `json:"id"` // GroupId represents the group ID of the member.
+	GroupId string `json:"id"`    // GroupId represents the group ID of the member.
+	Typ     string `json:"type"`  // Typ represents the element type of the group, such as "Controller" or "*pkg.Controller".
+	Name    string `json:"name"`  // Name represents the unique name of the member in the group, such as "user".
+	Order   int    `json:"order"` // Order represents the position of the member in the group, the members with lower orders come first.
 }
 
 // Injector represents an injector parameter.
 type Injector struct {
-	ProviderId string `json:"id"` // Id represents the identifier of the provider.
+	ProviderId string `json:"id"`       // Id represents the identifier of the provider.
+	Group      string `json:"group"`    // Group represents the identifier of the group whose members are injected as a slice.
+	Optional   bool   `json:"optional"` // Optional indicates that the zero value is injected if the provider does not exist.
 	Pkg        string
 	Param      string // Param represents the parameter name.
 	Alias      string
 
+	Key      string  `json:"-"` // Key represents the configuration key of the value injected by the @value annotation.
+	Env      string  `json:"-"` // Env represents the name of the environment variable injected by the @env annotation.
+	Required bool    `json:"-"` // Required indicates that the environment variable must be set.
+	Default  *string `json:"-"` // Default represents the default of the configuration value, nil if there is no default.
+
 	Typ        ast.Expr // Typ represents the type of the parameter.
 	Dependency *DiFunc
+	Profiles   DiFuncs // Profiles represents the providers of the injected ID in the other profiles, which are the dependencies as well.
+	Members    DiFuncs // Members represents the members of the injected group, which are the dependencies of the injector.
+}
+
+// Value represents a configuration value injected into a parameter.
+type Value struct {
+	Param   string          `json:"param"`   // Param represents the parameter name.
+	Key     string          `json:"key"`     // Key represents the configuration key, such as "db.url".
+	Default json.RawMessage `json:"default"` // Default represents the value used if the key is missing, such as "localhost:3306".
+}
+
+// Env represents an environment variable injected into a parameter.
+type Env struct {
+	Param    string          `json:"param"`    // Param represents the parameter name.
+	Name     string          `json:"name"`     // Name represents the name of the environment variable, such as "APP_PORT".
+	Required bool            `json:"required"` // Required indicates that the environment variable must be set.
+	Default  json.RawMessage `json:"default"`  // Default represents the value used if the variable is not set.
+}
+
+// Config represents a section of the configuration file bound to a struct.
+type Config struct {
+	Prefix string `json:"prefix"` // Prefix represents the dotted key of the section, such as "database".
+	Id     string `json:"id"`     // Id represents the provider ID of a struct type, "config." followed by the prefix by default.
+}
+
+// isConfig returns whether the injector injects a configuration value or an environment variable instead of an object.
+// isConfig 返回injector注入的是否是配置项或者环境变量，而不是对象
+func (i *Injector) isConfig() bool {
+	return len(i.Key) > 0 || len(i.Env) > 0
+}
+
+// elem returns the element type of the injected group, i.e. T of the parameter declared as []T.
+// elem 返回注入的组的元素类型，即参数声明为[]T时的T
+func (i *Injector) elem() ast.Expr {
+	if slice, ok := i.Typ.(*ast.ArrayType); ok {
+		return slice.Elt
+	}
+	return nil
 }
 
 // GetObjName returns the temporary variable name for the injector parameter, which has the "any" type.
@@ -98,9 +161,39 @@ func (i *Injector) GetObjName() string {
 }
 
 // GetArgName returns the variable name of the injector parameter, which is passed to the provider's constructor.
-// GetArgName 返回injector注解的变量名，这个变量用来传递给provider的构造函数
+// The name is the parameter name followed by "_dep", so that it never shadows the container c, the package digo
+// or the other variables of the generated code, such as err, member and env_vars.
+// GetArgName 返回injector注解的变量名，这个变量用来传递给provider的构造函数。
+// 变量名是参数名加上"_dep"，以免遮蔽容器c、digo包或者生成的代码中的其他变量，比如err、member和env_vars
 func (i *Injector) GetArgName() string {
-	return i.Param
+	return i.Param + "_dep"
+}
+
+// DiType represents a type expression from the source code together with the package that needs to be imported for it.
+// DiType 表示源码中的一个类型表达式，以及使用该类型时需要引入的包
+type DiType struct {
+	Expr  ast.Expr // Expr represents the type expression.
+	Pkg   string   // Pkg represents the import path of the package the type is defined in, empty for local or builtin types.
+	Alias string   // Alias represents the alias used when importing the package.
+}
+
+// String returns the textual representation of the type expression.
+func (t *DiType) String() string {
+	return types.ExprString(t.Expr)
+}
+
+// exportedName converts an ID into an exported Go identifier, e.g. "main.db" becomes "MainDb".
+// exportedName 将id转换为可导出的go标识符，比如"main.db"转换为"MainDb"
+func exportedName(id string) string {
+	fields := strings.FieldsFunc(id, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var name string
+	for _, field := range fields {
+		runes := []rune(field)
+		name += string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+	return name
 }
 
 // replaceSeparator replaces '.' or '/' in the ID with underscores.
@@ -114,13 +207,29 @@ func replaceSeparator(id string) string {
 // DiFunc represents a function with valid annotations.
 // DiFunc表示被合法的注解的函数
 type DiFunc struct {
-	Name       string
-	Injectors  []*Injector
-	ProviderId string
-	GroupId    string
-	Sort       int
-	Package    *DiPackage
-	File       *DiFile
+	Name        string
+	Injectors   []*Injector
+	ProviderId  string
+	GroupId     string
+	Lazy        bool
+	Scope       string
+	GroupTyp    *DiType // GroupTyp represents the element type of the group declared in the @group annotation.
+	MemberName  string  // MemberName represents the name of the member in the group declared in the @group annotation.
+	MemberOrder int     // MemberOrder represents the order of the member in the group declared in the @group annotation.
+	Source      string  // Source represents the file declaring the function, i.e. the package path followed by the file name.
+	Line        int     // Line represents the line of the function declaration in the source file.
+	Result      *DiType // Result represents the declared result type of the function.
+	ReturnsErr  bool    // ReturnsErr indicates that the function returns an error as its trailing result.
+	Sort        int
+	Package     *DiPackage
+	File        *DiFile
+
+	ConfigPrefix string // ConfigPrefix represents the section of the configuration file bound to the result by the @config annotation.
+	IsType       bool   // IsType indicates that it is a struct type annotated by @config instead of a function.
+	Profile      string // Profile represents the profile which the provider belongs to, empty if it belongs to every profile.
+
+	OnMissing     bool   // OnMissing indicates that the provider is registered only if no other provider has the same ID.
+	ConditionalOn string // ConditionalOn represents the ID of the provider which must exist for the provider to be registered.
 }
 
 // NewDiFunc creates a new DiFunc instance.
@@ -148,6 +257,11 @@ func (fn *DiFunc) providerObjName() string {
 // providerFuncName returns the name of the initialization function generated by the provider for registering the provider.
 // providerFuncName 返回provider生成的注册provider的初始化函数名
 func (fn *DiFunc) providerFuncName() string {
+	// The providers of the same ID in different profiles are registered by different functions.
+	// 不同profile中相同ID的provider由不同的函数注册
+	if len(fn.Profile) > 0 {
+		return "init_" + replaceSeparator(fn.ProviderId) + "_" + replaceSeparator(fn.Profile)
+	}
 	return "init_" + replaceSeparator(fn.ProviderId)
 }
 
@@ -157,6 +271,53 @@ func (fn *DiFunc) groupFuncName() string {
 	return "group_" + replaceSeparator(fn.GroupId) + "_" + fn.Name
 }
 
+// chainName returns the name of the function in a dependency chain, i.e. the provider ID,
+// or the group ID followed by the function name for a group member which is not a provider.
+// chainName 返回函数在依赖链中的名字，即provider的ID，不是provider的组成员则返回组的ID和函数名
+func (fn *DiFunc) chainName() string {
+	if len(fn.ProviderId) > 0 {
+		return fn.ProviderId
+	}
+	return fn.GroupId + "." + fn.Name
+}
+
+// isConditional returns whether the provider is registered only if its conditions are met.
+func (fn *DiFunc) isConditional() bool {
+	return fn.OnMissing || len(fn.ConditionalOn) > 0
+}
+
+// injectsConfig returns whether the constructor is injected with configuration values or environment variables.
+func (fn *DiFunc) injectsConfig() bool {
+	for _, injector := range fn.Injectors {
+		if injector.isConfig() {
+			return true
+		}
+	}
+	return false
+}
+
+// isPrototype returns whether the provider creates a new object every time it is provided.
+func (fn *DiFunc) isPrototype() bool {
+	return fn.Scope == "prototype"
+}
+
+// isRequestScoped returns whether the provider creates one object for each child scope of the container.
+func (fn *DiFunc) isRequestScoped() bool {
+	return fn.Scope == "request"
+}
+
+// providerGetterName returns the name of the generated typed getter of the provider.
+// providerGetterName 返回provider生成的带类型的获取函数名
+func (fn *DiFunc) providerGetterName() string {
+	return "Provide" + exportedName(fn.ProviderId)
+}
+
+// groupGetterName returns the name of the generated typed getter of the group.
+// groupGetterName 返回group生成的带类型的获取函数名
+func (fn *DiFunc) groupGetterName() string {
+	return "Members" + exportedName(fn.GroupId)
+}
+
 // DiFuncs represents an array of functions with valid annotations.
 // DiFuncs 表示被合法的注解的函数数组
 type DiFuncs []*DiFunc
@@ -213,18 +374,29 @@ type DiPackage struct {
 	Folder string
 	Funcs  DiFuncs
 	Files  map[string]*DiFile
+
+	// Imports represents the import paths of the packages imported directly or transitively,
+	// whose init() functions run before the init() of the package.
+	Imports map[string]bool
 }
 
 func NewDiPackage(name string, path string, folder string) *DiPackage {
 	return &DiPackage{
-		Name:   name,
-		Path:   path,
-		Folder: folder,
-		Funcs:  make(DiFuncs, 0),
-		Files:  make(map[string]*DiFile),
+		Name:    name,
+		Path:    path,
+		Folder:  folder,
+		Funcs:   make(DiFuncs, 0),
+		Files:   make(map[string]*DiFile),
+		Imports: make(map[string]bool),
 	}
 }
 
+// initializedBefore returns whether the init() functions of the other package run before the init() of the package.
+// initializedBefore 返回另一个包的init()函数是否在该包的init()之前执行
+func (pkg *DiPackage) initializedBefore(other *DiPackage) bool {
+	return other == pkg || pkg.Imports[other.Path]
+}
+
 // findProvider finds a provider by its ID within a package.
 // findProvider 根据provider id 从一个包中查找查找provider
 func (pkg *DiPackage) findProvider(id string) *DiFunc {
@@ -236,6 +408,17 @@ func (pkg *DiPackage) findProvider(id string) *DiFunc {
 	return nil
 }
 
+// findMember finds the member of the group with the name in the package.
+// findMember 在包中查找组中指定名字的成员
+func (pkg *DiPackage) findMember(groupId string, name string) *DiFunc {
+	for _, fn := range pkg.Funcs {
+		if groupId == fn.GroupId && name == fn.MemberName {
+			return fn
+		}
+	}
+	return nil
+}
+
 // Parser represents a parser for source code parsing.
 // Parser表示解析源码的解析器
 type Parser struct {
@@ -298,6 +481,54 @@ func (p *Parser) findProvider(id string) *DiFunc {
 	return nil
 }
 
+// findMember finds the member of the group with the name in all parsed packages.
+// findMember 在所有已解析的包中查找组中指定名字的成员
+func (p *Parser) findMember(groupId string, name string) *DiFunc {
+	for _, pkg := range p.Packages {
+		if fn := pkg.findMember(groupId, name); fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+// findMembers finds all members of the group in all parsed packages.
+// findMembers 在所有已解析的包中查找组的所有成员
+func (p *Parser) findMembers(groupId string) DiFuncs {
+	members := make(DiFuncs, 0)
+	for _, pkg := range p.Packages {
+		for _, fn := range pkg.Funcs {
+			if fn.GroupId == groupId {
+				members = append(members, fn)
+			}
+		}
+	}
+	return members
+}
+
+// qualifiedType returns the textual representation of the type expression used in the file, in which the types
+// are qualified with the import paths of their packages, so that the types used in different packages can be compared.
+// qualifiedType 返回文件中使用的类型表达式的文字表示，其中的类型使用它所在包的导入路径限定，以便比较不同包中使用的类型
+func (p *Parser) qualifiedType(file *DiFile, typ ast.Expr) string {
+	switch expr := typ.(type) {
+	case *ast.StarExpr:
+		return "*" + p.qualifiedType(file, expr.X)
+	case *ast.ArrayType:
+		if expr.Len == nil {
+			return "[]" + p.qualifiedType(file, expr.Elt)
+		}
+	case *ast.SelectorExpr:
+		if impor, ok := p.resolveTypeImport(file, expr); ok && impor != nil {
+			return impor.Path + "." + expr.Sel.Name
+		}
+	case *ast.Ident:
+		if types.Universe.Lookup(expr.Name) == nil && file != nil && file.Package != nil {
+			return file.Package.Path + "." + expr.Name
+		}
+	}
+	return types.ExprString(typ)
+}
+
 // parseProvider analyzes and extracts all the @provider annotations in the source code,
 // and saves the annotation information in the Provider object.
 // parseProvider分析提取源码中所有的@provider注解，并将注解信息保存在Provider对象中。
@@ -307,13 +538,172 @@ func (p *Parser) parseProvider(body string, fn *DiFunc) error {
 		return fmt.Errorf("wrong JSON format: %s", err.Error())
 	}
 
-	if p.findProvider(provider.Id) != nil || fn.Package.findProvider(provider.Id) != nil {
-		return fmt.Errorf("[ERROR] duplicate provider ID: %s", provider.Id)
+	fn.Profile = provider.Profile
+	fn.OnMissing = provider.OnMissing
+	fn.ConditionalOn = provider.ConditionalOn
+
+	// The conditions are resolved across all profiles, so a conditional provider belongs to every profile.
+	// 条件是跨所有profile解析的，所以有条件的provider属于所有的profile
+	if fn.isConditional() && len(fn.Profile) > 0 {
+		return fmt.Errorf("conditional provider %s cannot have a profile", provider.Id)
+	}
+	if provider.ConditionalOn == provider.Id {
+		return fmt.Errorf("provider %s cannot be conditional on itself", provider.Id)
 	}
+	if err := p.checkDuplicateProvider(provider.Id, fn); err != nil {
+		return err
+	}
+	switch provider.Scope {
+	case "", "singleton", "prototype", "request":
+	default:
+		return fmt.Errorf("unsupported scope: %s", provider.Scope)
+	}
+
 	fn.ProviderId = provider.Id
+	fn.Lazy = provider.Lazy
+	fn.Scope = provider.Scope
+	return nil
+}
+
+// checkDuplicateProvider returns an error if the provider ID is already used in the parsed packages or the package of the function.
+// The same ID can be used only by the providers of distinct profiles, since a provider without a profile belongs to every profile,
+// or by the conditional providers, which are checked again after their conditions are resolved.
+// checkDuplicateProvider 如果provider ID已经在已解析的包或者函数所在的包中使用，则返回错误。
+// 只有不同profile的provider可以使用相同的ID，因为没有profile的provider属于所有的profile，
+// 或者是有条件的provider，它们会在条件解析之后再次检查
+func (p *Parser) checkDuplicateProvider(id string, fn *DiFunc) error {
+	others := p.findProvidersById(id)
+	for _, other := range fn.Package.Funcs {
+		if other.ProviderId == id {
+			others = append(others, other)
+		}
+	}
+
+	for _, other := range others {
+		if fn.isConditional() || other.isConditional() {
+			continue
+		}
+		if len(fn.Profile) == 0 || len(other.Profile) == 0 || fn.Profile == other.Profile {
+			return fmt.Errorf("[ERROR] duplicate provider ID: %s", id)
+		}
+	}
 	return nil
 }
 
+// parseConfig analyzes the @config annotation of a provider, e.g. @config({"prefix":"database"}),
+// the object returned by the provider is populated with the section of the configuration file.
+// parseConfig 分析provider的@config注解，provider返回的对象会使用配置文件中的配置段进行填充
+func (p *Parser) parseConfig(body string, fn *DiFunc) error {
+	config := &Config{}
+	if err := json.Unmarshal([]byte(body), config); err != nil {
+		return fmt.Errorf("wrong JSON format: %s", err.Error())
+	}
+	if len(config.Prefix) == 0 {
+		return errors.New("config prefix is empty")
+	}
+	if len(config.Id) > 0 {
+		return errors.New("config id can only be used on a struct type, use the @provider annotation instead")
+	}
+	fn.ConfigPrefix = config.Prefix
+	return nil
+}
+
+// parseType analyzes the @config annotation of a struct type, e.g. @config({"prefix":"database"}),
+// and registers a lazy singleton provider of the pointer to the struct populated with the section of the configuration file.
+// The provider ID is "config." followed by the prefix unless it is specified by the id of the annotation.
+// parseType 分析结构体类型的@config注解，并注册一个延迟创建的单例provider，它提供使用配置文件中的配置段填充的结构体指针。
+// 除非注解中使用id指定，否则provider的ID为"config."加上prefix
+func (p *Parser) parseType(pkg *DiPackage, fn *DiFunc, spec *ast.TypeSpec, doc *ast.CommentGroup) error {
+	if doc == nil {
+		return nil
+	}
+	for _, comment := range doc.List {
+		name, body := p.matchComment(comment.Text)
+		if name != "config" {
+			continue
+		}
+
+		config := &Config{}
+		if err := json.Unmarshal([]byte(body), config); err != nil {
+			return fmt.Errorf("failed to parse config annotation, wrong JSON format: %s in package: %s Type: %s", err.Error(), pkg.Path, fn.Name)
+		}
+		if len(config.Prefix) == 0 {
+			return fmt.Errorf("failed to parse config annotation, config prefix is empty in package: %s Type: %s", pkg.Path, fn.Name)
+		}
+		if _, ok := spec.Type.(*ast.StructType); !ok || spec.TypeParams != nil {
+			return fmt.Errorf("failed to parse config annotation, only a non-generic struct type can be bound in package: %s Type: %s", pkg.Path, fn.Name)
+		}
+
+		id := config.Id
+		if len(id) == 0 {
+			id = "config." + config.Prefix
+		}
+		if err := p.checkDuplicateProvider(id, fn); err != nil {
+			return fmt.Errorf("failed to parse config annotation, %s in package: %s Type: %s", err.Error(), pkg.Path, fn.Name)
+		}
+
+		fn.ProviderId = id
+		fn.ConfigPrefix = config.Prefix
+		fn.IsType = true
+		fn.Lazy = true
+		fn.Result = &DiType{Expr: &ast.StarExpr{X: newIdent(fn.Name)}}
+	}
+	return nil
+}
+
+// newDiType creates a new DiType with the type expression and the package that needs to be imported for it.
+func newDiType(expr ast.Expr, impor *DiImport) *DiType {
+	typ := &DiType{Expr: expr}
+	if impor != nil {
+		typ.Pkg = impor.Path
+		typ.Alias = impor.Name
+	}
+	return typ
+}
+
+// resolveTypeImport finds the package that needs to be imported for the type expression in the import list of the file.
+// It returns a nil import if the type is defined in the current package or is a builtin type,
+// and returns false if the package required by the type cannot be found.
+// resolveTypeImport 从文件的import列表中查找类型表达式需要引入的包
+// 如果该类型是当前包中定义的类型或者是内置类型，返回的import为nil，如果找不到需要的包则返回false
+func (p *Parser) resolveTypeImport(file *DiFile, typ ast.Expr) (*DiImport, bool) {
+	// Determine the type, which can be one of the following: regular types (int, string, struct, etc.),
+	// pointer types (*type), compound pointer types (*pkg.type), compound regular types (pkg.type) or slices of them.
+	// 判断类型，类型可能是下面几种 (int, string, struct等)普通类型，
+	// *type指针类型， *pkg.type复合指针类型, pkg.type复合普通类型，以及它们的切片类型
+	switch expr := typ.(type) {
+	case *ast.StarExpr:
+		// For a pointer type (*type or *pkg.type), resolve the type it points to.
+		// 如果是指针类型，则解析指针指向的类型
+		return p.resolveTypeImport(file, expr.X)
+
+	case *ast.ArrayType:
+		// For a slice or array type ([]type or []pkg.type), resolve the element type.
+		// 如果是切片或者数组类型，则解析元素的类型
+		return p.resolveTypeImport(file, expr.Elt)
+
+	case *ast.SelectorExpr:
+		// If it is a compound regular type (pkg.struct), it indicates that this type requires importing a package from elsewhere.
+		// Find the package in the import list of the current file, if an alias is used when importing the package,
+		// the alias is returned as the name of the import as well.
+		// 如果是pkg.struct这种类型，说明这个类型需要引入别的地方的包
+		// 从当前文件的import列表中，将需要引入的包找出来，如果引入包的时候使用了别名，那么别名也需要返回
+		ident, ok := expr.X.(*ast.Ident)
+		if !ok || file == nil {
+			return nil, false
+		}
+		impor, ok := file.Imports[ident.Name]
+		return impor, ok
+
+	case *ast.Ident:
+		// If it is a regular type (int, string, struct, etc.), it indicates that this type uses
+		// a type defined in the current package, and no additional package needs to be imported.
+		// 如果是(int, string, struct等)普通类型，说明这个类型使用的当前包中定义的type，不需要额外的引入包了
+		return nil, true
+	}
+	return nil, false
+}
+
 // parseInject analyzes all the @inject annotations in the source code and extracts the inject information into an Injector object.
 // parseInject 分析源码码中所有的@inject注解，并将inject信息提取到Injector对象中
 func (p *Parser) parseInject(body string, fn *DiFunc, decl *ast.FuncDecl) error {
@@ -322,19 +712,47 @@ func (p *Parser) parseInject(body string, fn *DiFunc, decl *ast.FuncDecl) error
 		return err
 	}
 
+	injector.Typ = paramType(decl, injector.Param)
+	if injector.Typ == nil {
+		return errors.New("injected parameter is not found")
+	}
+
+	// A whole group can be injected as a slice of its element type, e.g., @inject({"param":"ctrls", "group":"controllers"})
+	// on the parameter ctrls []Controller.
+	// 整个组可以作为元素类型的切片注入，比如在参数ctrls []Controller上使用@inject({"param":"ctrls", "group":"controllers"})
+	if len(injector.Group) > 0 {
+		if len(injector.ProviderId) > 0 {
+			return fmt.Errorf("injected parameter %s cannot have both id and group", injector.Param)
+		}
+		if slice, ok := injector.Typ.(*ast.ArrayType); !ok || slice.Len != nil {
+			return fmt.Errorf("injected group parameter %s must be a slice", injector.Param)
+		}
+		if injector.Optional {
+			return fmt.Errorf("injected group parameter %s cannot be optional", injector.Param)
+		}
+	}
+	return p.addInjector(fn, injector)
+}
+
+// paramType returns the type of the parameter with the name in the function declaration, nil if it is not found.
+// paramType 返回函数声明中指定名字的参数的类型，找不到则返回nil
+func paramType(decl *ast.FuncDecl, param string) ast.Expr {
 	// Traverse the AST tree of the current function's source code and check if all annotations can be found in the parameter list.
 	// 遍历当前函数的源码ast数，检测所有的注解在参数列表中是否都能找到
-	for i, field := range decl.Type.Params.List {
+	var typ ast.Expr
+	for _, field := range decl.Type.Params.List {
 		for _, name := range field.Names {
-			if name.Name == injector.Param {
-				injector.Typ = decl.Type.Params.List[i].Type
+			if name.Name == param {
+				typ = field.Type
 			}
 		}
 	}
-	if injector.Typ == nil {
-		return errors.New("injected parameter is not found")
-	}
+	return typ
+}
 
+// addInjector resolves the package to import for the type of the injected parameter, and adds the injector to the function.
+// addInjector 解析被注入参数的类型需要引入的包，并将injector添加到函数中
+func (p *Parser) addInjector(fn *DiFunc, injector *Injector) error {
 	// The @inject annotation can explicitly specify the package name for the variable,
 	// e.g., @inject({"param": "mq", "id": "mq", "pkg": "github.com/mochi-co/mqtt/v2"}).
 	// If the package to be imported is explicitly specified in the @inject annotation, there is no need to search for imported packages.
@@ -352,67 +770,104 @@ func (p *Parser) parseInject(body string, fn *DiFunc, decl *ast.FuncDecl) error
 	// 如果injector里的param对应的参数的类型不是当前包下定义的，需要引入别的地方的包
 	// 比如需要注入一个参数类型是: eventbus.EventBus, 这个类型是包github.com/werbenhu/eventbus里定义的
 	// 这里需要从当前文件的import列表中，找出这个包名
-	var importPkg string
-
-	// isPkgFound indicates whether the package required for the variable has been found in the import list of the current file.
-	// isPkgFound标识是否从当前文件的import列表中找到了该变量需要使用的包
-	var isPkgFound bool
-
-	// Determine the type of the injected parameter, which can be one of the following: regular types (int, string, struct, etc.),
-	// pointer types (*type), compound pointer types (*pkg.type), or compound regular types (pkg.type).
-	// 判断inject的参数的类型，类型可能是下面三种 (int, string, struct等)普通类型，
-	// *type指针类型， *pkg.type复合指针类型, pkg.type复合普通类型
-	if starExpr, ok := injector.Typ.(*ast.StarExpr); ok {
+	impor, isPkgFound := p.resolveTypeImport(fn.File, injector.Typ)
+	if impor != nil {
+		injector.Pkg = impor.Path
+		injector.Alias = impor.Name
+	}
 
-		// If it is a compound pointer type (*pkg.type), it indicates that this parameter requires importing a package.
-		// 如果*pkg.type复合指针类型，说明这个参数需要引入包
-		if selExpr, ok := starExpr.X.(*ast.SelectorExpr); ok {
-			importPkg = selExpr.X.(*ast.Ident).Name
+	if !isPkgFound {
+		return errors.New("injected parameter's package not found")
+	}
+	fn.Injectors = append(fn.Injectors, injector)
+	return nil
+}
 
-			// Find the package path and alias in the import list of the source code file for the package required by this parameter, and save it in the injector.
-			// 从源码文件的import列表中，找出该参数需要引入的包的路径和别名保存在injector中
-			if impor, ok := fn.File.Imports[importPkg]; ok {
-				injector.Pkg = impor.Path
-				injector.Alias = impor.Name
-				isPkgFound = true
-			}
+// parseValue analyzes the @value annotations in the source code and extracts the configuration value information
+// into an Injector object, e.g. @value({"param":"url", "key":"db.url", "default":"localhost:3306"}).
+// parseValue 分析源码中的@value注解，并将配置项信息提取到Injector对象中
+func (p *Parser) parseValue(body string, fn *DiFunc, decl *ast.FuncDecl) error {
+	value := &Value{}
+	if err := json.Unmarshal([]byte(body), value); err != nil {
+		return err
+	}
+	if len(value.Key) == 0 {
+		return fmt.Errorf("config key of parameter %s is empty", value.Param)
+	}
 
-		} else if _, ok := starExpr.X.(*ast.Ident); ok {
-			// If it is a pointer type (*type), it indicates that this parameter uses a struct defined in the current package, and no additional package needs to be imported.
-			// 如果是*type指针类型，说明这个参数使用的当前包中定义的struct，这种类型不需要额外的引入包了
-			isPkgFound = true
+	injector := &Injector{Param: value.Param, Key: value.Key, Typ: paramType(decl, value.Param)}
+	if injector.Typ == nil {
+		return errors.New("injected parameter is not found")
+	}
+	if len(value.Default) > 0 {
+		def, err := defaultValue(value.Default)
+		if err != nil {
+			return fmt.Errorf("invalid default of config key %s: %s", value.Key, err.Error())
 		}
+		injector.Default = &def
+	}
+	return p.addInjector(fn, injector)
+}
 
-	} else if selExpr, ok := injector.Typ.(*ast.SelectorExpr); ok {
-		// If it is a compound regular type (pkg.struct), it indicates that this parameter requires importing a package from elsewhere.
-		// 如果是pkg.struct这种类型的参数，说明这个参数需要引入别的地方的包
-		importPkg = selExpr.X.(*ast.Ident).Name
-
-		// Find the package required by this parameter in the import list of the current file,
-		// and save the package path and alias in the injector.
-		// If an alias is used when importing the package, it also needs to be saved here.
-		// 从当前文件的import列表中，将需要引入的包找出来，并放在injector中
-		// 如果引入包的时候使用了别名，那么这里别名也需要保存
-		if selExpr.X != nil {
-			if impor, ok := fn.File.Imports[importPkg]; ok {
-				injector.Pkg = impor.Path
-				injector.Alias = impor.Name
-				isPkgFound = true
+// defaultValue converts the default in the JSON format into the raw value of the configuration, the elements
+// of an array are separated by commas, e.g. "localhost:3306", 3306, true or ["a", "b"].
+// defaultValue 将JSON格式的默认值转换为原始的配置值，数组的元素以逗号分隔
+func defaultValue(raw json.RawMessage) (string, error) {
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str, nil
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err == nil {
+		fields := make([]string, len(elems))
+		for i, elem := range elems {
+			field, err := defaultValue(elem)
+			if err != nil {
+				return "", err
 			}
+			fields[i] = field
 		}
+		return strings.Join(fields, ","), nil
+	}
 
-	} else if _, ok := injector.Typ.(*ast.Ident); ok {
-		// If it is a regular type (int, string, struct, etc.), it indicates that this parameter uses
-		// a type defined in the current package, and no additional package needs to be imported.
-		// 如果是(int, string, struct等)普通类型，说明这个参数使用的当前包中定义的type，不需要额外的引入包了
-		isPkgFound = true
+	var scalar any
+	if err := json.Unmarshal(raw, &scalar); err != nil {
+		return "", err
+	}
+	switch scalar.(type) {
+	case float64, bool:
+		return strings.TrimSpace(string(raw)), nil
 	}
+	return "", fmt.Errorf("unsupported value %s", raw)
+}
 
-	if !isPkgFound {
-		return errors.New("injected parameter's package not found")
+// parseEnv analyzes the @env annotations in the source code and extracts the environment variable information
+// into an Injector object, e.g. @env({"param":"port", "name":"APP_PORT", "required":true}).
+// parseEnv 分析源码中的@env注解，并将环境变量信息提取到Injector对象中
+func (p *Parser) parseEnv(body string, fn *DiFunc, decl *ast.FuncDecl) error {
+	env := &Env{}
+	if err := json.Unmarshal([]byte(body), env); err != nil {
+		return err
 	}
-	fn.Injectors = append(fn.Injectors, injector)
-	return nil
+	if len(env.Name) == 0 {
+		return fmt.Errorf("environment variable name of parameter %s is empty", env.Param)
+	}
+	if env.Required && len(env.Default) > 0 {
+		return fmt.Errorf("required environment variable %s cannot have a default", env.Name)
+	}
+
+	injector := &Injector{Param: env.Param, Env: env.Name, Required: env.Required, Typ: paramType(decl, env.Param)}
+	if injector.Typ == nil {
+		return errors.New("injected parameter is not found")
+	}
+	if len(env.Default) > 0 {
+		def, err := defaultValue(env.Default)
+		if err != nil {
+			return fmt.Errorf("invalid default of environment variable %s: %s", env.Name, err.Error())
+		}
+		injector.Default = &def
+	}
+	return p.addInjector(fn, injector)
 }
 
 // parseGroup analyzes and extracts all the @group annotations in the source code and saves the annotation information in a Member object.
@@ -423,6 +878,78 @@ func (p *Parser) parseGroup(body string, fn *DiFunc) error {
 		return err
 	}
 	fn.GroupId = member.GroupId
+	fn.MemberOrder = member.Order
+
+	// The name of a member must be unique in the group across all packages.
+	// 成员的名字在所有包的同一个组中必须是唯一的
+	if len(member.Name) > 0 {
+		if other := p.findMember(member.GroupId, member.Name); other != nil {
+			return fmt.Errorf("duplicate member name %s in group %s, already used by %s.%s", member.Name, member.GroupId, other.Package.Path, other.Name)
+		}
+		if other := fn.Package.findMember(member.GroupId, member.Name); other != nil {
+			return fmt.Errorf("duplicate member name %s in group %s, already used by %s.%s", member.Name, member.GroupId, other.Package.Path, other.Name)
+		}
+		fn.MemberName = member.Name
+	}
+
+	// The @group annotation can declare the element type of the group, e.g., @group({"id":"controllers", "type":"Controller"}),
+	// which is used to generate a typed getter of the group.
+	// @group注解可以声明组的元素类型，比如@group({"id":"controllers", "type":"Controller"})，用来生成带类型的获取函数
+	if len(member.Typ) > 0 {
+		expr, err := goparser.ParseExpr(member.Typ)
+		if err != nil {
+			return fmt.Errorf("invalid group type: %s", member.Typ)
+		}
+		impor, ok := p.resolveTypeImport(fn.File, expr)
+		if !ok {
+			return errors.New("group type's package not found")
+		}
+		fn.GroupTyp = newDiType(expr, impor)
+
+		// All members of a group in the same package must declare the same element type.
+		// 同一个包中同一个组的所有成员必须声明相同的元素类型
+		for _, other := range fn.Package.Funcs {
+			if other.GroupId == fn.GroupId && other.GroupTyp != nil && other.GroupTyp.String() != fn.GroupTyp.String() {
+				return fmt.Errorf("conflicting types of group %s: %s and %s", fn.GroupId, other.GroupTyp, fn.GroupTyp)
+			}
+		}
+	}
+	return nil
+}
+
+// parseResult extracts the declared result type of the function, which must not return more than one value,
+// except for a trailing error. The result type is ignored if its package cannot be found.
+// parseResult 提取函数声明的返回值类型，函数除了最后的error之外不能返回多个值，如果找不到返回值类型的包，则忽略返回值类型
+func (p *Parser) parseResult(fn *DiFunc, decl *ast.FuncDecl) error {
+	results := make([]ast.Expr, 0)
+	if decl.Type.Results != nil {
+		for _, field := range decl.Type.Results.List {
+			results = append(results, field.Type)
+
+			// A field with multiple names declares multiple results of the same type, e.g. (a, b *Db).
+			// 一个字段有多个名字表示声明了多个相同类型的返回值
+			for i := 1; i < len(field.Names); i++ {
+				results = append(results, field.Type)
+			}
+		}
+	}
+
+	if len(results) == 2 {
+		if ident, ok := results[1].(*ast.Ident); ok && ident.Name == "error" {
+			fn.ReturnsErr = true
+			results = results[:1]
+		}
+	}
+	if len(results) > 1 {
+		return errors.New("the function must return one value, or one value and an error")
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	if impor, ok := p.resolveTypeImport(fn.File, results[0]); ok {
+		fn.Result = newDiType(results[0], impor)
+	}
 	return nil
 }
 
@@ -450,13 +977,58 @@ func (p *Parser) parseFunc(pkg *DiPackage, fn *DiFunc, decl *ast.FuncDecl) error
 				if err := p.parseGroup(body, fn); err != nil {
 					return fmt.Errorf("failed to parse group annotation, %s in package: %s Func: %s", err.Error(), pkg.Path, fn.Name)
 				}
+			case "value":
+				if err := p.parseValue(body, fn, decl); err != nil {
+					return fmt.Errorf("failed to parse value annotation, %s in package: %s Func: %s", err.Error(), pkg.Path, fn.Name)
+				}
+			case "env":
+				if err := p.parseEnv(body, fn, decl); err != nil {
+					return fmt.Errorf("failed to parse env annotation, %s in package: %s Func: %s", err.Error(), pkg.Path, fn.Name)
+				}
+			case "config":
+				if err := p.parseConfig(body, fn); err != nil {
+					return fmt.Errorf("failed to parse config annotation, %s in package: %s Func: %s", err.Error(), pkg.Path, fn.Name)
+				}
 			}
 		}
 	}
 
+	// The object of a provider bound to the configuration file is populated after the file is loaded by LoadConfig
+	// in main(), so a singleton is created lazily instead of in init().
+	// 绑定到配置文件的provider的对象在main()中通过LoadConfig加载配置文件之后才能填充，所以单例需要延迟创建，而不是在init()中创建
+	if len(fn.ConfigPrefix) > 0 {
+		if len(fn.ProviderId) == 0 || len(fn.GroupId) > 0 {
+			return fmt.Errorf("config annotation can only be used on a provider which is not a group member, in pkg: %s, function: %s", pkg.Path, fn.Name)
+		}
+		if !fn.isPrototype() && !fn.isRequestScoped() {
+			fn.Lazy = true
+		}
+	}
+
+	// The providers of a profile are registered when the profile is activated on the first lookup,
+	// so a singleton is created lazily, and the members of the groups, which are registered in init(), cannot have a profile.
+	// profile的provider在第一次查找时激活profile的时候注册，所以单例需要延迟创建，而在init()中注册的组成员不能有profile
+	if len(fn.Profile) > 0 {
+		if len(fn.GroupId) > 0 {
+			return fmt.Errorf("provider of profile %s cannot be a member of a group, in pkg: %s, function: %s", fn.Profile, pkg.Path, fn.Name)
+		}
+		if !fn.isPrototype() && !fn.isRequestScoped() {
+			fn.Lazy = true
+		}
+	}
+
 	if len(fn.ProviderId) == 0 && len(fn.GroupId) == 0 {
 		return nil
 	}
+	if err := p.parseResult(fn, decl); err != nil {
+		return fmt.Errorf("%s, in pkg: %s, function: %s", err.Error(), pkg.Path, fn.Name)
+	}
+
+	// The members of a group are created in init(), where no child scope exists.
+	// 组的成员是在init()中创建的，这时还不存在子作用域
+	if fn.isRequestScoped() && len(fn.GroupId) > 0 {
+		return fmt.Errorf("request scoped provider cannot be a member of a group, in pkg: %s, function: %s", pkg.Path, fn.Name)
+	}
 
 	// Check if all parameters of the function have been injected
 	// 检查是否函数的所有参数都被注入了
@@ -486,6 +1058,7 @@ func (p *Parser) parse(pkgs []*packages.Package) error {
 		splitted := strings.Split(pkg.GoFiles[0], string(os.PathSeparator))
 		folder := strings.Join(splitted[:len(splitted)-1], string(os.PathSeparator))
 		diPkg := NewDiPackage(pkg.Name, pkg.PkgPath, folder)
+		addImports(diPkg, pkg)
 
 		for _, syntax := range pkg.Syntax {
 			diFile := NewDiFile(diPkg, syntax.Name.String())
@@ -493,9 +1066,20 @@ func (p *Parser) parse(pkgs []*packages.Package) error {
 
 				if genDecl, ok := decl.(*ast.GenDecl); ok {
 					p.parseImports(diPkg, diFile, genDecl)
+					if err := p.parseTypes(diPkg, diFile, genDecl); err != nil {
+						return err
+					}
 				} else if fn, ok := decl.(*ast.FuncDecl); ok {
 
 					diFunc := NewDiFunc(diPkg, diFile, fn.Name.String())
+
+					// The source position is used to sort the members of a group deterministically.
+					// 源码位置用于对组的成员进行确定的排序
+					if pkg.Fset != nil {
+						position := pkg.Fset.Position(fn.Pos())
+						diFunc.Source = pkg.PkgPath + "/" + filepath.Base(position.Filename)
+						diFunc.Line = position.Line
+					}
 					if err := p.parseFunc(diPkg, diFunc, fn); err != nil {
 						return err
 					}
@@ -517,6 +1101,48 @@ func (p *Parser) parse(pkgs []*packages.Package) error {
 	return nil
 }
 
+// addImports adds the import paths of the packages imported by pkg directly or transitively to the package.
+// addImports 将pkg直接或者间接引入的包的路径添加到包中
+func addImports(diPkg *DiPackage, pkg *packages.Package) {
+	for _, imported := range pkg.Imports {
+		if !diPkg.Imports[imported.PkgPath] {
+			diPkg.Imports[imported.PkgPath] = true
+			addImports(diPkg, imported)
+		}
+	}
+}
+
+// parseTypes analyzes the comments of the types declared in the declaration, and adds the struct types
+// annotated by @config to the package as providers.
+// parseTypes 解析声明中的类型的注释，并将@config注解的结构体类型作为provider添加到包中
+func (p *Parser) parseTypes(pkg *DiPackage, file *DiFile, decl *ast.GenDecl) error {
+	if decl.Tok != token.TYPE {
+		return nil
+	}
+	for _, spec := range decl.Specs {
+		typeSpec, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		// The comments of a single type declaration without parentheses belong to the declaration.
+		// 不带括号的单个类型声明的注释属于整个声明
+		doc := typeSpec.Doc
+		if doc == nil && len(decl.Specs) == 1 {
+			doc = decl.Doc
+		}
+
+		fn := NewDiFunc(pkg, file, typeSpec.Name.String())
+		if err := p.parseType(pkg, fn, typeSpec, doc); err != nil {
+			return err
+		}
+		if len(fn.ProviderId) > 0 {
+			pkg.Funcs = append(pkg.Funcs, fn)
+		}
+	}
+	return nil
+}
+
 // findProviderById finds a provider by ID.
 // findProviderById 根据id查找provider
 func (p *Parser) findProviderById(id string) *DiFunc {
@@ -530,6 +1156,115 @@ func (p *Parser) findProviderById(id string) *DiFunc {
 	return nil
 }
 
+// resolveConditions resolves the conditions of the conditional providers, and removes the providers whose conditions
+// are not met, so that the generated code registers a deterministic set of providers:
+//
+//   - A provider conditional on another ID is removed if no provider of that ID remains, which is repeated
+//     until no more providers are removed, since the removed providers can be the conditions of others.
+//   - A fallback provider marked by onMissing is removed if another provider of the same ID remains.
+//
+// It returns false if several conditional providers of the same ID remain.
+// resolveConditions 解析有条件的provider的条件，并删除条件不满足的provider，以便生成的代码注册确定的provider集合：
+// 依赖于其他ID的provider，如果该ID已经没有provider则被删除，这个过程会一直重复直到没有provider被删除，因为被删除的provider
+// 可能是其他provider的条件；onMissing标记的后备provider，如果存在相同ID的其他provider则被删除。
+// 如果同一个ID剩下了多个有条件的provider，则返回false
+func (p *Parser) resolveConditions() bool {
+	remove := func(fn *DiFunc, reason string) {
+		log.Printf("[INFO] provider id:%s is not registered since %s, in package:%s, func:%s",
+			fn.ProviderId, reason, fn.Package.Path, fn.Name)
+		funcs := make(DiFuncs, 0, len(fn.Package.Funcs))
+		for _, other := range fn.Package.Funcs {
+			if other != fn {
+				funcs = append(funcs, other)
+			}
+		}
+		fn.Package.Funcs = funcs
+	}
+
+	for removed := true; removed; {
+		removed = false
+		for _, pkg := range p.Packages {
+			for _, fn := range pkg.Funcs {
+				if len(fn.ConditionalOn) > 0 && len(p.findProvidersById(fn.ConditionalOn)) == 0 {
+					remove(fn, fmt.Sprintf("provider id:%s is missing", fn.ConditionalOn))
+					removed = true
+				}
+			}
+		}
+	}
+
+	for _, pkg := range p.Packages {
+		for _, fn := range pkg.Funcs {
+			if !fn.OnMissing {
+				continue
+			}
+			for _, other := range p.findProvidersById(fn.ProviderId) {
+				if !other.OnMissing {
+					remove(fn, fmt.Sprintf("it is provided by package:%s, func:%s", other.Package.Path, other.Name))
+					break
+				}
+			}
+		}
+	}
+
+	for _, pkg := range p.Packages {
+		for _, fn := range pkg.Funcs {
+			for _, other := range p.findProvidersById(fn.ProviderId) {
+				if other != fn && (fn.isConditional() || other.isConditional()) {
+					log.Printf("[ERROR] duplicate provider ID: %s, registered by both package:%s, func:%s and package:%s, func:%s",
+						fn.ProviderId, fn.Package.Path, fn.Name, other.Package.Path, other.Name)
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// findProvidersById finds the providers of the ID in all profiles.
+// findProvidersById 查找所有profile中指定id的provider
+func (p *Parser) findProvidersById(id string) DiFuncs {
+	providers := make(DiFuncs, 0)
+	for _, pkg := range p.Packages {
+		for _, fn := range pkg.Funcs {
+			if fn.ProviderId == id {
+				providers = append(providers, fn)
+			}
+		}
+	}
+	return providers
+}
+
+// checkGetterNames checks if the typed getters generated in each package have distinct names, since distinct IDs
+// can be converted into the same name, e.g. "main.db" and "main_db" both into ProvideMainDb.
+// It returns false if the getters of two IDs have the same name.
+// checkGetterNames 检查每个包中生成的带类型的获取函数的名字是否互不相同，因为不同的ID可能会转换为相同的名字，
+// 比如"main.db"和"main_db"都会转换为ProvideMainDb。如果两个ID的获取函数名字相同则返回false
+func (p *Parser) checkGetterNames() bool {
+	for _, pkg := range p.Packages {
+		ids := make(map[string]string)
+		check := func(name string, id string, kind string) bool {
+			if other, ok := ids[name]; ok && other != id {
+				log.Printf("[ERROR] getter %s of %s id:%s conflicts with the getter of id:%s, in package:%s",
+					name, kind, id, other, pkg.Path)
+				return false
+			}
+			ids[name] = id
+			return true
+		}
+		for _, fn := range pkg.Funcs {
+			if len(fn.ProviderId) > 0 && fn.Result != nil && !fn.isRequestScoped() &&
+				!check(fn.providerGetterName(), fn.ProviderId, "provider") {
+				return false
+			}
+			if len(fn.GroupId) > 0 && fn.GroupTyp != nil && !check(fn.groupGetterName(), fn.GroupId, "group") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // checkInjectorLegal checks if the injected object is legal and returns false if the required provider does not exist.
 // checkInjectorLegal 检查注入的对象是否合法，如果需要注入的provider不存在则返回false
 func (p *Parser) checkInjectorLegal() bool {
@@ -538,13 +1273,199 @@ func (p *Parser) checkInjectorLegal() bool {
 			// Find the provider to which each injector belongs.
 			// 查找出每个injector所归属的provider
 			for _, injector := range fn.Injectors {
+				// A configuration value or an environment variable is resolved at runtime instead of by a provider.
+				// 配置项和环境变量在运行时解析，而不是从provider中获取
+				if injector.isConfig() {
+					continue
+				}
+				if len(injector.Group) > 0 {
+					if !p.checkGroupInjector(pkg, fn, injector) {
+						return false
+					}
+					continue
+				}
+
 				provider := p.findProviderById(injector.ProviderId)
+
+				// An optional provider may be registered by another module or by hand, the zero value is injected otherwise.
+				// 可选的provider可能由其他模块或者手动注册，否则会注入零值
+				if provider == nil && injector.Optional {
+					continue
+				}
 				if provider == nil {
 					log.Printf("[ERROR] provider id:%s not found, used in package:%s, func:%s, param:%s",
 						injector.ProviderId, pkg.Path, fn.Name, injector.Param)
 					return false
 				}
 				injector.Dependency = provider
+
+				// The providers of the same ID in the other profiles are the dependencies as well.
+				// 其他profile中相同ID的provider同样是依赖
+				injector.Profiles = make(DiFuncs, 0)
+				for _, other := range p.findProvidersById(injector.ProviderId) {
+					if other != provider {
+						injector.Profiles = append(injector.Profiles, other)
+					}
+				}
+
+				// Only request scoped providers and prototypes can depend on request scoped providers,
+				// since a singleton outlives the child scopes.
+				// 只有请求作用域的provider和原型可以依赖请求作用域的provider，因为单例的生命周期比子作用域长
+				for _, dependency := range append(DiFuncs{provider}, injector.Profiles...) {
+					if dependency.isRequestScoped() && !fn.isRequestScoped() && !fn.isPrototype() {
+						log.Printf("[ERROR] singleton cannot depend on request scoped provider id:%s, used in package:%s, func:%s, param:%s",
+							injector.ProviderId, pkg.Path, fn.Name, injector.Param)
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// checkGroupInjector checks if the injected group is legal, the group must have members and the element type
+// declared by the members must be the element type of the injected slice. It returns false if the group is illegal.
+// checkGroupInjector 检查注入的组是否合法，组必须存在成员，并且成员声明的元素类型必须和注入的切片的元素类型相同，不合法则返回false
+func (p *Parser) checkGroupInjector(pkg *DiPackage, fn *DiFunc, injector *Injector) bool {
+	members := p.findMembers(injector.Group)
+	if len(members) == 0 {
+		log.Printf("[ERROR] group id:%s not found, used in package:%s, func:%s, param:%s",
+			injector.Group, pkg.Path, fn.Name, injector.Param)
+		return false
+	}
+
+	// The members without a declared element type are checked when the slice is built at runtime.
+	// 没有声明元素类型的成员会在运行时构建切片的时候进行检查
+	elem := p.qualifiedType(fn.File, injector.elem())
+	for _, member := range members {
+		if member.GroupTyp != nil && p.qualifiedType(member.File, member.GroupTyp.Expr) != elem {
+			log.Printf("[ERROR] group id:%s of type %s cannot be injected as %s, used in package:%s, func:%s, param:%s",
+				injector.Group, member.GroupTyp, types.ExprString(injector.Typ), pkg.Path, fn.Name, injector.Param)
+			return false
+		}
+	}
+	injector.Members = members
+	return true
+}
+
+// checkProfiles checks if each profile forms a complete graph, i.e. every provider injected into the providers
+// which belong to the profile is provided by a provider of the profile or by a provider without a profile.
+// It returns false if a provider is missing in a profile.
+// checkProfiles 检查每个profile是否构成完整的依赖图，即属于该profile的provider注入的每个provider，
+// 都由该profile的provider或者没有profile的provider提供，如果某个profile中缺少provider则返回false
+func (p *Parser) checkProfiles() bool {
+	profiles := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, pkg := range p.Packages {
+		for _, fn := range pkg.Funcs {
+			if len(fn.Profile) > 0 && !seen[fn.Profile] {
+				seen[fn.Profile] = true
+				profiles = append(profiles, fn.Profile)
+			}
+		}
+	}
+	sort.Strings(profiles)
+
+	for _, profile := range profiles {
+		active := func(fn *DiFunc) bool {
+			return len(fn.Profile) == 0 || fn.Profile == profile
+		}
+		for _, pkg := range p.Packages {
+			for _, fn := range pkg.Funcs {
+				if !active(fn) {
+					continue
+				}
+				for _, injector := range fn.Injectors {
+					if injector.isConfig() || len(injector.Group) > 0 || injector.Optional {
+						continue
+					}
+					found := false
+					for _, provider := range p.findProvidersById(injector.ProviderId) {
+						found = found || active(provider)
+					}
+					if !found {
+						log.Printf("[ERROR] provider id:%s not found in profile %s, used in package:%s, func:%s, param:%s",
+							injector.ProviderId, profile, pkg.Path, fn.Name, injector.Param)
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// deferredDependency returns the first provider or group member injected into the function which is deferred,
+// or which is registered by a package whose init() does not run before the init() of the function's package, or nil.
+// deferredDependency 返回函数注入的第一个被推迟的provider或者组成员，或者注册它的包的init()不在函数所在的包的init()之前执行，
+// 如果没有则返回nil
+func deferredDependency(fn *DiFunc, deferred map[*DiFunc]bool) *DiFunc {
+	for _, injector := range fn.Injectors {
+		dependencies := append(DiFuncs{}, injector.Profiles...)
+		dependencies = append(dependencies, injector.Members...)
+		if injector.Dependency != nil {
+			dependencies = append(dependencies, injector.Dependency)
+		}
+		for _, dependency := range dependencies {
+			if deferred[dependency] || !fn.Package.initializedBefore(dependency.Package) {
+				return dependency
+			}
+		}
+	}
+	return nil
+}
+
+// checkLazyDependents makes the singletons lazy which depend directly or transitively on the providers of a profile,
+// on the providers bound to the configuration file or on the providers injected with @value or @env, since those
+// providers are registered when the profile is activated in main(), populated after the file is loaded by LoadConfig
+// in main(), or resolved from the source set by SetConfigSource in main(), and a singleton created in init() would
+// activate the profile before it is set, or bind the configuration before it is loaded or set.
+// So are the fallbacks marked by onMissing and the singletons depending on them, since another provider of the ID
+// may be registered by a package generated separately and initialized after them.
+// So are the singletons which depend on the providers or the group members of a package which is not imported,
+// since nothing guarantees that the package is initialized before them.
+// It returns false if a member of a group, which is always created in init(), is one of them or depends on them.
+// checkLazyDependents 将直接或者间接依赖profile的provider、绑定到配置文件的provider或者通过@value、@env注入的provider的单例设置为延迟创建，
+// 因为这些provider在main()中激活profile的时候才注册，在main()中通过LoadConfig加载配置文件之后才能填充，
+// 或者从main()中通过SetConfigSource设置的配置源中解析，在init()中创建单例会在设置profile之前就激活profile，
+// 或者在加载或设置配置之前就绑定配置。onMissing标记的后备provider以及依赖它们的单例也是如此，
+// 因为单独生成代码的包可能会在它们之后初始化，并注册该ID的其他provider。
+// 依赖没有被引入的包中的provider或者组成员的单例也是如此，因为无法保证这些包在它们之前初始化。
+// 如果某个组的成员是这些provider或者依赖了这些provider则返回false，因为组的成员总是在init()中创建
+func (p *Parser) checkLazyDependents() bool {
+	deferred := make(map[*DiFunc]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, pkg := range p.Packages {
+			for _, fn := range pkg.Funcs {
+				if !deferred[fn] && (len(fn.Profile) > 0 || len(fn.ConfigPrefix) > 0 || fn.injectsConfig() || fn.OnMissing ||
+					deferredDependency(fn, deferred) != nil) {
+					deferred[fn] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	for _, pkg := range p.Packages {
+		for _, fn := range pkg.Funcs {
+			if !deferred[fn] {
+				continue
+			}
+			if len(fn.GroupId) > 0 {
+				dependency := deferredDependency(fn, deferred)
+				if dependency == nil {
+					log.Printf("[ERROR] member of group id:%s cannot be injected with configuration values or environment variables, in package:%s, func:%s",
+						fn.GroupId, pkg.Path, fn.Name)
+					return false
+				}
+				log.Printf("[ERROR] member of group id:%s cannot depend on func:%s of package:%s, which depends on a profile, the configuration or a fallback or is in a package not imported, in package:%s, func:%s",
+					fn.GroupId, dependency.Name, dependency.Package.Path, pkg.Path, fn.Name)
+				return false
+			}
+			if !fn.isPrototype() && !fn.isRequestScoped() {
+				fn.Lazy = true
 			}
 		}
 	}
@@ -571,6 +1492,24 @@ func (p *Parser) increaseProviderPrioritys(c chain, fn *DiFunc) bool {
 				return false
 			}
 		}
+
+		// The providers of the injected ID in all profiles are the dependencies of the provider.
+		// 所有profile中被注入ID的provider都是provider的依赖
+		for _, dependency := range injector.Profiles {
+			dependency.Sort++
+			if !p.increaseProviderPrioritys(clone.clone(), dependency) {
+				return false
+			}
+		}
+
+		// All members of an injected group are the dependencies of the provider.
+		// 注入的组的所有成员都是provider的依赖
+		for _, member := range injector.Members {
+			member.Sort++
+			if !p.increaseProviderPrioritys(clone.clone(), member) {
+				return false
+			}
+		}
 	}
 	return true
 }
@@ -591,6 +1530,15 @@ func (p *Parser) checkCyclicProvider() bool {
 	return true
 }
 
+// check resolves the conditional providers, checks the legality of injectors, profiles, getter names and
+// cyclic provider dependencies, and marks the lazy dependents. It returns false if any check fails.
+// check 解析有条件的provider，检查injector、profile、获取函数名以及provider循环依赖是否合法，并标记延迟创建的依赖者，
+// 任何检查失败则返回false
+func (p *Parser) check() bool {
+	return p.resolveConditions() && p.checkGetterNames() && p.checkInjectorLegal() && p.checkProfiles() &&
+		p.checkCyclicProvider() && p.checkLazyDependents()
+}
+
 // Start initiates the annotation analysis, generates Go code, and writes it to files.
 // Start 启动分析注解，并生成go代码，写入到文件中
 func (p *Parser) Start() {
@@ -614,7 +1562,7 @@ func (p *Parser) Start() {
 	}
 
 	// Check the legality of injectors and cyclic provider dependencies.
-	if p.checkInjectorLegal() && p.checkCyclicProvider() {
+	if p.check() {
 		// Generate Go code.
 		for _, pkg := range p.Packages {
 			generator := NewGenerator(pkg)
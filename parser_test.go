@@ -114,7 +114,7 @@ func TestInjector_GetArgName(t *testing.T) {
 		Param: "param",
 	}
 
-	expected := "param"
+	expected := "param_dep"
 	result := injector.GetArgName()
 	require.Equal(t, result, expected)
 }
@@ -392,6 +392,8 @@ func TestParser_ParseProvider(t *testing.T) {
 	assert.NoError(t, err, "Expected no error")
 	assert.Equal(t, expectedId, fn.ProviderId, "Expected provider ID to match")
 
+	assert.False(t, fn.Lazy, "Expected provider not to be lazy")
+
 	// Test case 2: Invalid JSON format
 	body = "invalid json"
 	err = parser.parseProvider(body, fn)
@@ -871,3 +873,752 @@ func TestParser_CheckCyclicProvider(t *testing.T) {
 	result = parser.checkCyclicProvider()
 	assert.False(t, result, "Expected circular dependency")
 }
+
+func TestExportedName(t *testing.T) {
+	assert.Equal(t, "MainDb", exportedName("main.db"))
+	assert.Equal(t, "MainDbUrl", exportedName("main.db.url"))
+	assert.Equal(t, "GroupControllers", exportedName("group/controllers"))
+	assert.Equal(t, "ModelUserName", exportedName("model_user-name"))
+}
+
+func TestDiFunc_getterNames(t *testing.T) {
+	fn := &DiFunc{
+		ProviderId: "main.db",
+		GroupId:    "group.controllers",
+	}
+	assert.Equal(t, "ProvideMainDb", fn.providerGetterName())
+	assert.Equal(t, "MembersGroupControllers", fn.groupGetterName())
+}
+
+func TestParser_CheckGetterNames(t *testing.T) {
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	result := &DiType{Expr: &ast.StarExpr{X: newIdent("Db")}}
+	dev := &DiFunc{Name: "NewFakeDb", ProviderId: "main.db", Profile: "dev", Result: result, Package: pkg}
+	prod := &DiFunc{Name: "NewDb", ProviderId: "main.db", Profile: "prod", Result: result, Package: pkg}
+	ctrl := &DiFunc{Name: "NewUserController", GroupId: "main.controllers", GroupTyp: &DiType{Expr: newIdent("Controller")}, Package: pkg}
+
+	// The providers of the same ID share one getter
+	parser := NewParser()
+	parser.Packages = []*DiPackage{pkg}
+	pkg.Funcs = DiFuncs{dev, prod, ctrl}
+	assert.True(t, parser.checkGetterNames())
+
+	// Distinct IDs cannot be converted into the same getter name
+	for _, id := range []string{"main_db", "main-db"} {
+		other := &DiFunc{Name: "NewOtherDb", ProviderId: id, Result: result, Package: pkg}
+		pkg.Funcs = DiFuncs{dev, prod, other}
+		assert.False(t, parser.checkGetterNames())
+	}
+	other := &DiFunc{Name: "NewRoleController", GroupId: "main_controllers", GroupTyp: &DiType{Expr: newIdent("Controller")}, Package: pkg}
+	pkg.Funcs = DiFuncs{ctrl, other}
+	assert.False(t, parser.checkGetterNames())
+
+	// A request scoped provider has no getter
+	other = &DiFunc{Name: "NewTx", ProviderId: "main_db", Scope: "request", Result: result, Package: pkg}
+	pkg.Funcs = DiFuncs{dev, other}
+	assert.True(t, parser.checkGetterNames())
+}
+
+func TestParser_ResolveTypeImport(t *testing.T) {
+	parser := NewParser()
+	file := &DiFile{
+		Imports: map[string]*DiImport{
+			"pkg": {Name: "alias", Path: "github.com/my/pkg"},
+		},
+	}
+
+	// Types defined in the current package do not need any import
+	impor, ok := parser.resolveTypeImport(file, newIdent("Db"))
+	assert.True(t, ok)
+	assert.Nil(t, impor)
+
+	// Pointers and slices are resolved by their element types
+	for _, typ := range []ast.Expr{
+		newSelectorExpr("pkg.Db"),
+		newStarExpr("pkg.Db"),
+		&ast.ArrayType{Elt: newStarExpr("pkg.Db")},
+	} {
+		impor, ok = parser.resolveTypeImport(file, typ)
+		assert.True(t, ok)
+		assert.Equal(t, "github.com/my/pkg", impor.Path)
+		assert.Equal(t, "alias", impor.Name)
+	}
+
+	// Packages that are not imported cannot be resolved
+	_, ok = parser.resolveTypeImport(file, newStarExpr("other.Db"))
+	assert.False(t, ok)
+	_, ok = parser.resolveTypeImport(file, &ast.MapType{Key: newIdent("string"), Value: newIdent("Db")})
+	assert.False(t, ok)
+}
+
+func TestParser_ParseGroup_WithType(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "/path/to/example", "/path/to/folder")
+	file := NewDiFile(pkg, "example.go")
+	file.Imports["ctrl"] = &DiImport{Path: "github.com/my/ctrl"}
+
+	fn := NewDiFunc(pkg, file, "NewUserController")
+	err := parser.parseGroup("{\"id\":\"controllers\", \"type\":\"ctrl.Controller\"}", fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "ctrl.Controller", fn.GroupTyp.String())
+	assert.Equal(t, "github.com/my/ctrl", fn.GroupTyp.Pkg)
+	pkg.Funcs = append(pkg.Funcs, fn)
+
+	// Members of the same group must declare the same type
+	fn = NewDiFunc(pkg, file, "NewRoleController")
+	err = parser.parseGroup("{\"id\":\"controllers\", \"type\":\"*RoleController\"}", fn)
+	assert.EqualError(t, err, "conflicting types of group controllers: ctrl.Controller and *RoleController")
+
+	fn = NewDiFunc(pkg, file, "NewRoleController")
+	err = parser.parseGroup("{\"id\":\"controllers\", \"type\":\"unknown.Controller\"}", fn)
+	assert.EqualError(t, err, "group type's package not found")
+
+	fn = NewDiFunc(pkg, file, "NewRoleController")
+	err = parser.parseGroup("{\"id\":\"controllers\", \"type\":\"[\"}", fn)
+	assert.EqualError(t, err, "invalid group type: [")
+}
+
+func TestParser_ParseResult(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "/path/to/example", "/path/to/folder")
+	file := NewDiFile(pkg, "example.go")
+	file.Imports["pkg"] = &DiImport{Path: "github.com/my/pkg"}
+
+	decl := &ast.FuncDecl{
+		Type: &ast.FuncType{
+			Results: &ast.FieldList{
+				List: []*ast.Field{{Type: newStarExpr("pkg.Db")}},
+			},
+		},
+	}
+	fn := NewDiFunc(pkg, file, "NewDb")
+	parser.parseResult(fn, decl)
+	assert.NotNil(t, fn.Result)
+	assert.Equal(t, "*pkg.Db", fn.Result.String())
+	assert.Equal(t, "github.com/my/pkg", fn.Result.Pkg)
+
+	// Functions without exactly one result have no result type
+	fn = NewDiFunc(pkg, file, "NewDb")
+	parser.parseResult(fn, declRegular)
+	assert.Nil(t, fn.Result)
+}
+
+func TestParser_ParseProvider_Lazy(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "/path/to/example", "/path/to/folder")
+	fn := NewDiFunc(pkg, NewDiFile(pkg, "example.go"), "NewDb")
+
+	err := parser.parseProvider("{\"id\":\"main.db\", \"lazy\":true}", fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "main.db", fn.ProviderId)
+	assert.True(t, fn.Lazy, "Expected provider to be lazy")
+}
+
+func TestParser_ParseProvider_Scope(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "/path/to/example", "/path/to/folder")
+	fn := NewDiFunc(pkg, NewDiFile(pkg, "example.go"), "NewRequest")
+
+	err := parser.parseProvider("{\"id\":\"main.req\", \"scope\":\"prototype\"}", fn)
+	assert.NoError(t, err)
+	assert.True(t, fn.isPrototype(), "Expected provider to be a prototype")
+
+	fn = NewDiFunc(pkg, NewDiFile(pkg, "example.go"), "NewRequest")
+	err = parser.parseProvider("{\"id\":\"main.req\", \"scope\":\"session\"}", fn)
+	assert.EqualError(t, err, "unsupported scope: session")
+}
+
+func TestParser_RequestScope(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "github.com/my/package", "/path/to/folder")
+	file := NewDiFile(pkg, "example.go")
+
+	// Request scoped providers cannot be members of a group
+	decl := &ast.FuncDecl{
+		Doc: newCommentGroup([]string{
+			"// @provider({\"id\": \"main.tx\", \"scope\": \"request\"})",
+			"// @group({\"id\": \"txs\"})",
+		}),
+		Type: emptyType,
+	}
+	err := parser.parseFunc(pkg, NewDiFunc(pkg, file, "NewTx"), decl)
+	assert.EqualError(t, err, "request scoped provider cannot be a member of a group, in pkg: github.com/my/package, function: NewTx")
+
+	tx := &DiFunc{ProviderId: "main.tx", Scope: "request"}
+	handler := &DiFunc{ProviderId: "main.handler", Scope: "prototype", Injectors: []*Injector{{ProviderId: "main.tx"}}}
+	parser.Packages = []*DiPackage{{Funcs: DiFuncs{tx, handler}}}
+	assert.True(t, parser.checkInjectorLegal(), "Expected prototype to depend on request scoped provider")
+
+	// Singletons cannot depend on request scoped providers
+	service := &DiFunc{ProviderId: "main.service", Injectors: []*Injector{{ProviderId: "main.tx"}}}
+	parser.Packages = []*DiPackage{{Funcs: DiFuncs{tx, service}}}
+	assert.False(t, parser.checkInjectorLegal(), "Expected singleton not to depend on request scoped provider")
+}
+
+func TestParser_ParseResult_Error(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "/path/to/example", "/path/to/folder")
+	file := NewDiFile(pkg, "example.go")
+
+	// A trailing error is accepted
+	decl := &ast.FuncDecl{
+		Type: &ast.FuncType{
+			Results: &ast.FieldList{
+				List: []*ast.Field{{Type: newIdent("Db")}, {Type: newIdent("error")}},
+			},
+		},
+	}
+	fn := NewDiFunc(pkg, file, "NewDb")
+	assert.NoError(t, parser.parseResult(fn, decl))
+	assert.True(t, fn.ReturnsErr)
+	assert.Equal(t, "Db", fn.Result.String())
+
+	// Other results are rejected
+	decl.Type.Results.List[1].Type = newIdent("string")
+	fn = NewDiFunc(pkg, file, "NewDb")
+	assert.EqualError(t, parser.parseResult(fn, decl), "the function must return one value, or one value and an error")
+
+	decl.Type.Results.List = []*ast.Field{{Names: []*ast.Ident{newIdent("a"), newIdent("b")}, Type: newIdent("Db")}}
+	fn = NewDiFunc(pkg, file, "NewDb")
+	assert.Error(t, parser.parseResult(fn, decl))
+}
+
+func TestParser_ParseGroup_WithName(t *testing.T) {
+	parser := NewParser()
+	other := NewDiPackage("other", "github.com/my/other", "/path/to/other")
+	other.Funcs = append(other.Funcs, &DiFunc{Name: "NewAdminController", GroupId: "controllers", MemberName: "admin", Package: other})
+	parser.Packages = append(parser.Packages, other)
+
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	file := NewDiFile(pkg, "example.go")
+
+	fn := NewDiFunc(pkg, file, "NewUserController")
+	err := parser.parseGroup("{\"id\":\"controllers\", \"name\":\"user\"}", fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "user", fn.MemberName)
+	pkg.Funcs = append(pkg.Funcs, fn)
+
+	// The same name can be used in another group
+	fn = NewDiFunc(pkg, file, "NewUserMiddleware")
+	assert.NoError(t, parser.parseGroup("{\"id\":\"middlewares\", \"name\":\"user\"}", fn))
+
+	// Duplicate names in a group are reported, in the same package or across packages
+	fn = NewDiFunc(pkg, file, "NewRoleController")
+	err = parser.parseGroup("{\"id\":\"controllers\", \"name\":\"user\"}", fn)
+	assert.EqualError(t, err, "duplicate member name user in group controllers, already used by github.com/my/example.NewUserController")
+
+	err = parser.parseGroup("{\"id\":\"controllers\", \"name\":\"admin\"}", fn)
+	assert.EqualError(t, err, "duplicate member name admin in group controllers, already used by github.com/my/other.NewAdminController")
+}
+
+func TestParser_ParseGroup_WithOrder(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	fn := NewDiFunc(pkg, NewDiFile(pkg, "example.go"), "NewRecoverMiddleware")
+
+	err := parser.parseGroup("{\"id\":\"middlewares\", \"order\":-10}", fn)
+	assert.NoError(t, err)
+	assert.Equal(t, -10, fn.MemberOrder)
+
+	err = parser.parseGroup("{\"id\":\"middlewares\", \"order\":\"first\"}", fn)
+	assert.Error(t, err)
+}
+
+func TestParser_ParseInject_Group(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	fn := NewDiFunc(pkg, NewDiFile(pkg, "example.go"), "NewRouter")
+	decl := &ast.FuncDecl{
+		Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{
+			{Names: []*ast.Ident{newIdent("ctrls")}, Type: &ast.ArrayType{Elt: newIdent("Controller")}},
+			{Names: []*ast.Ident{newIdent("ctrl")}, Type: newIdent("Controller")},
+		}}},
+	}
+
+	// A group is injected into a slice parameter
+	err := parser.parseInject("{\"param\":\"ctrls\", \"group\":\"controllers\"}", fn, decl)
+	assert.NoError(t, err)
+	assert.Equal(t, "controllers", fn.Injectors[0].Group)
+	assert.Equal(t, newIdent("Controller"), fn.Injectors[0].elem())
+
+	err = parser.parseInject("{\"param\":\"ctrl\", \"group\":\"controllers\"}", fn, decl)
+	assert.EqualError(t, err, "injected group parameter ctrl must be a slice")
+
+	err = parser.parseInject("{\"param\":\"ctrls\", \"id\":\"main.ctrls\", \"group\":\"controllers\"}", fn, decl)
+	assert.EqualError(t, err, "injected parameter ctrls cannot have both id and group")
+}
+
+func TestParser_CheckGroupInjector(t *testing.T) {
+	app := NewDiPackage("main", "github.com/my/app", "/app")
+	appFile := NewDiFile(app, "main.go")
+	appFile.Imports["ctrl"] = &DiImport{Name: "ctrl", Path: "github.com/my/app/controllers"}
+	ctrls := NewDiPackage("controllers", "github.com/my/app/controllers", "/app/controllers")
+	ctrlsFile := NewDiFile(ctrls, "user.go")
+
+	user := &DiFunc{Name: "NewUserController", GroupId: "controllers", File: ctrlsFile, Package: ctrls,
+		GroupTyp: &DiType{Expr: newIdent("Controller")}}
+	role := &DiFunc{Name: "NewRoleController", GroupId: "controllers", File: ctrlsFile, Package: ctrls}
+	router := &DiFunc{Name: "NewRouter", ProviderId: "main.router", File: appFile, Package: app, Injectors: []*Injector{{
+		Param: "ctrls",
+		Group: "controllers",
+		Typ:   &ast.ArrayType{Elt: &ast.SelectorExpr{X: newIdent("ctrl"), Sel: newIdent("Controller")}},
+	}}}
+	app.Funcs = DiFuncs{router}
+	ctrls.Funcs = DiFuncs{user, role}
+	parser := &Parser{Packages: []*DiPackage{app, ctrls}}
+
+	// The element type used in another package matches the type declared by the members
+	assert.True(t, parser.checkInjectorLegal())
+	assert.Equal(t, DiFuncs{user, role}, router.Injectors[0].Members)
+
+	// The element type must match the type declared by the members
+	router.Injectors[0].Typ = &ast.ArrayType{Elt: newIdent("Controller")}
+	assert.False(t, parser.checkInjectorLegal())
+
+	// The group must have members
+	router.Injectors[0].Group = "handlers"
+	assert.False(t, parser.checkInjectorLegal())
+}
+
+func TestParser_CheckCyclicProvider_Group(t *testing.T) {
+	name := &DiFunc{Name: "NewUserName", ProviderId: "main.user.name"}
+	user := &DiFunc{Name: "NewUserController", GroupId: "controllers", Injectors: []*Injector{{Dependency: name}}}
+	router := &DiFunc{Name: "NewRouter", ProviderId: "main.router", Injectors: []*Injector{{Group: "controllers", Members: DiFuncs{user}}}}
+	parser := &Parser{Packages: []*DiPackage{{Funcs: DiFuncs{router, user, name}}}}
+
+	// The members of an injected group are registered before the provider, and their dependencies before them
+	assert.True(t, parser.checkCyclicProvider())
+	assert.Equal(t, DiFuncs{name, user, router}, parser.Packages[0].Funcs)
+
+	// A member which depends on the provider the group is injected into is a circular dependency
+	user.Injectors = append(user.Injectors, &Injector{Dependency: router})
+	assert.False(t, parser.increaseProviderPrioritys(newChain(), router))
+	assert.Equal(t, "main.router -> controllers.NewUserController", chain{router, user}.String())
+}
+
+func TestParser_CheckInjectorLegal_Optional(t *testing.T) {
+	app := &DiFunc{Name: "NewApp", ProviderId: "main.app", Injectors: []*Injector{{
+		Param:      "cache",
+		ProviderId: "main.cache",
+		Optional:   true,
+	}}}
+	parser := &Parser{Packages: []*DiPackage{{Path: "github.com/my/app", Funcs: DiFuncs{app}}}}
+
+	// An optional provider does not need to exist
+	assert.True(t, parser.checkInjectorLegal())
+	assert.Nil(t, app.Injectors[0].Dependency)
+	assert.True(t, parser.checkCyclicProvider())
+
+	// The provider is a dependency if it exists
+	cache := &DiFunc{Name: "NewCache", ProviderId: "main.cache"}
+	parser.Packages[0].Funcs = DiFuncs{app, cache}
+	assert.True(t, parser.checkInjectorLegal())
+	assert.Same(t, cache, app.Injectors[0].Dependency)
+
+	// A group cannot be optional
+	decl := &ast.FuncDecl{Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{
+		{Names: []*ast.Ident{newIdent("ctrls")}, Type: &ast.ArrayType{Elt: newIdent("Controller")}},
+	}}}}
+	err := NewParser().parseInject("{\"param\":\"ctrls\", \"group\":\"controllers\", \"optional\":true}", app, decl)
+	assert.EqualError(t, err, "injected group parameter ctrls cannot be optional")
+}
+
+func TestParser_ParseValue(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	file := NewDiFile(pkg, "example.go")
+	file.Imports["time"] = &DiImport{Name: "time", Path: "time"}
+	fn := NewDiFunc(pkg, file, "NewDb")
+	decl := &ast.FuncDecl{
+		Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{
+			{Names: []*ast.Ident{newIdent("url")}, Type: newIdent("string")},
+			{Names: []*ast.Ident{newIdent("timeout")}, Type: &ast.SelectorExpr{X: newIdent("time"), Sel: newIdent("Duration")}},
+			{Names: []*ast.Ident{newIdent("hosts")}, Type: &ast.ArrayType{Elt: newIdent("string")}},
+		}}},
+	}
+
+	err := parser.parseValue("{\"param\":\"url\", \"key\":\"db.url\", \"default\":\"localhost:3306\"}", fn, decl)
+	assert.NoError(t, err)
+	assert.Equal(t, "db.url", fn.Injectors[0].Key)
+	assert.Equal(t, "localhost:3306", *fn.Injectors[0].Default)
+
+	// The package of the parameter's type is imported, and the value has no default
+	err = parser.parseValue("{\"param\":\"timeout\", \"key\":\"db.timeout\"}", fn, decl)
+	assert.NoError(t, err)
+	assert.Equal(t, "time", fn.Injectors[1].Pkg)
+	assert.Nil(t, fn.Injectors[1].Default)
+
+	// The elements of an array default are separated by commas
+	err = parser.parseValue("{\"param\":\"hosts\", \"key\":\"db.hosts\", \"default\":[\"a\", 1, true]}", fn, decl)
+	assert.NoError(t, err)
+	assert.Equal(t, "a,1,true", *fn.Injectors[2].Default)
+
+	err = parser.parseValue("{\"param\":\"hosts\", \"key\":\"db.hosts\", \"default\":{}}", fn, decl)
+	assert.EqualError(t, err, "invalid default of config key db.hosts: unsupported value {}")
+
+	err = parser.parseValue("{\"param\":\"url\"}", fn, decl)
+	assert.EqualError(t, err, "config key of parameter url is empty")
+
+	err = parser.parseValue("{\"param\":\"port\", \"key\":\"db.port\"}", fn, decl)
+	assert.EqualError(t, err, "injected parameter is not found")
+
+	// The values do not need providers
+	parser.Packages = []*DiPackage{pkg}
+	pkg.Funcs = DiFuncs{fn}
+	assert.True(t, parser.checkInjectorLegal())
+}
+
+func TestParser_ParseEnv(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	fn := NewDiFunc(pkg, NewDiFile(pkg, "example.go"), "NewServer")
+	decl := &ast.FuncDecl{
+		Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{
+			{Names: []*ast.Ident{newIdent("port"), newIdent("host")}, Type: newIdent("int")},
+		}}},
+	}
+
+	err := parser.parseEnv("{\"param\":\"port\", \"name\":\"APP_PORT\", \"required\":true}", fn, decl)
+	assert.NoError(t, err)
+	assert.Equal(t, "APP_PORT", fn.Injectors[0].Env)
+	assert.True(t, fn.Injectors[0].Required)
+	assert.True(t, fn.Injectors[0].isConfig())
+
+	err = parser.parseEnv("{\"param\":\"host\", \"name\":\"APP_HOST\", \"default\":8080}", fn, decl)
+	assert.NoError(t, err)
+	assert.Equal(t, "8080", *fn.Injectors[1].Default)
+
+	err = parser.parseEnv("{\"param\":\"host\", \"name\":\"APP_HOST\", \"required\":true, \"default\":8080}", fn, decl)
+	assert.EqualError(t, err, "required environment variable APP_HOST cannot have a default")
+
+	err = parser.parseEnv("{\"param\":\"host\"}", fn, decl)
+	assert.EqualError(t, err, "environment variable name of parameter host is empty")
+
+	// The environment variables do not need providers
+	parser.Packages = []*DiPackage{pkg}
+	pkg.Funcs = DiFuncs{fn}
+	assert.True(t, parser.checkInjectorLegal())
+}
+
+func TestParser_ParseConfig(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	fn := NewDiFunc(pkg, NewDiFile(pkg, "example.go"), "NewDbConfig")
+
+	err := parser.parseConfig("{\"prefix\":\"database\"}", fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "database", fn.ConfigPrefix)
+
+	err = parser.parseConfig("{}", fn)
+	assert.EqualError(t, err, "config prefix is empty")
+
+	err = parser.parseConfig("{\"prefix\":\"database\", \"id\":\"main.db\"}", fn)
+	assert.Error(t, err)
+
+	// A singleton provider bound to the configuration file is created lazily
+	decl := &ast.FuncDecl{
+		Name: newIdent("NewDbConfig"),
+		Doc: newCommentGroup([]string{
+			"// @provider({\"id\":\"main.dbconfig\"})",
+			"// @config({\"prefix\":\"database\"})",
+		}),
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: &ast.StarExpr{X: newIdent("DbConfig")}}}},
+		},
+	}
+	fn = NewDiFunc(pkg, NewDiFile(pkg, "example.go"), "NewDbConfig")
+	assert.NoError(t, parser.parseFunc(pkg, fn, decl))
+	assert.True(t, fn.Lazy)
+
+	// The annotation requires a provider
+	decl.Doc = newCommentGroup([]string{"// @config({\"prefix\":\"database\"})"})
+	fn = NewDiFunc(pkg, NewDiFile(pkg, "example.go"), "NewDbConfig")
+	assert.Error(t, parser.parseFunc(pkg, fn, decl))
+}
+
+func TestParser_Parse_ConfigType(t *testing.T) {
+	parser := NewParser()
+	structType := &ast.StructType{Fields: &ast.FieldList{}}
+	pkg := &packages.Package{
+		Name:    "example",
+		PkgPath: "github.com/my/example",
+		GoFiles: []string{"file.go"},
+		Syntax: []*ast.File{{
+			Name: newIdent("file"),
+			Decls: []ast.Decl{
+				&ast.GenDecl{
+					Tok: token.TYPE,
+					Doc: newCommentGroup([]string{"// @config({\"prefix\":\"server\"})"}),
+					Specs: []ast.Spec{
+						&ast.TypeSpec{Name: newIdent("ServerConfig"), Type: structType},
+					},
+				},
+				&ast.GenDecl{
+					Tok: token.TYPE,
+					Specs: []ast.Spec{
+						&ast.TypeSpec{Name: newIdent("Server"), Type: structType},
+						&ast.TypeSpec{
+							Name: newIdent("DbConfig"),
+							Doc:  newCommentGroup([]string{"// @config({\"prefix\":\"database\", \"id\":\"main.dbconfig\"})"}),
+							Type: structType,
+						},
+					},
+				},
+			},
+		}},
+	}
+
+	assert.NoError(t, parser.parse([]*packages.Package{pkg}))
+	assert.Len(t, parser.Packages, 1)
+	funcs := parser.Packages[0].Funcs
+	assert.Len(t, funcs, 2)
+
+	assert.Equal(t, "config.server", funcs[0].ProviderId)
+	assert.Equal(t, "server", funcs[0].ConfigPrefix)
+	assert.True(t, funcs[0].IsType)
+	assert.True(t, funcs[0].Lazy)
+	assert.Equal(t, "*ServerConfig", funcs[0].Result.String())
+
+	assert.Equal(t, "main.dbconfig", funcs[1].ProviderId)
+	assert.Equal(t, "database", funcs[1].ConfigPrefix)
+
+	// Only struct types can be bound, and the derived IDs must be unique
+	parser = NewParser()
+	pkg.Syntax[0].Decls = []ast.Decl{&ast.GenDecl{
+		Tok:   token.TYPE,
+		Doc:   newCommentGroup([]string{"// @config({\"prefix\":\"server\"})"}),
+		Specs: []ast.Spec{&ast.TypeSpec{Name: newIdent("Port"), Type: newIdent("int")}},
+	}}
+	assert.Error(t, parser.parse([]*packages.Package{pkg}))
+
+	parser = NewParser()
+	decl := &ast.GenDecl{
+		Tok:   token.TYPE,
+		Doc:   newCommentGroup([]string{"// @config({\"prefix\":\"server\"})"}),
+		Specs: []ast.Spec{&ast.TypeSpec{Name: newIdent("ServerConfig"), Type: structType}},
+	}
+	pkg.Syntax[0].Decls = []ast.Decl{decl, decl}
+	err := parser.parse([]*packages.Package{pkg})
+	assert.ErrorContains(t, err, "duplicate provider ID: config.server")
+}
+
+func TestParser_ParseProvider_Profile(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "/path/to/example", "/path/to/folder")
+	file := NewDiFile(pkg, "example.go")
+
+	dev := NewDiFunc(pkg, file, "NewFakeMailer")
+	assert.NoError(t, parser.parseProvider("{\"id\":\"main.mailer\", \"profile\":\"dev\"}", dev))
+	assert.Equal(t, "dev", dev.Profile)
+	assert.Equal(t, "init_main_mailer_dev", dev.providerFuncName())
+	pkg.Funcs = append(pkg.Funcs, dev)
+
+	// The same ID can be used by distinct profiles only
+	prod := NewDiFunc(pkg, file, "NewSmtpMailer")
+	assert.NoError(t, parser.parseProvider("{\"id\":\"main.mailer\", \"profile\":\"prod\"}", prod))
+	pkg.Funcs = append(pkg.Funcs, prod)
+
+	other := NewDiFunc(pkg, file, "NewOtherMailer")
+	err := parser.parseProvider("{\"id\":\"main.mailer\", \"profile\":\"dev\"}", other)
+	assert.EqualError(t, err, "[ERROR] duplicate provider ID: main.mailer")
+	err = parser.parseProvider("{\"id\":\"main.mailer\"}", other)
+	assert.EqualError(t, err, "[ERROR] duplicate provider ID: main.mailer")
+
+	// A singleton of a profile is created lazily, and it cannot be a member of a group
+	decl := &ast.FuncDecl{
+		Name: newIdent("NewQueue"),
+		Doc:  newCommentGroup([]string{"// @provider({\"id\":\"main.queue\", \"profile\":\"dev\"})"}),
+		Type: emptyType,
+	}
+	queue := NewDiFunc(pkg, file, "NewQueue")
+	assert.NoError(t, parser.parseFunc(pkg, queue, decl))
+	assert.True(t, queue.Lazy)
+
+	decl.Doc.List = append(decl.Doc.List, &ast.Comment{Text: "// @group({\"id\":\"queues\"})"})
+	assert.Error(t, parser.parseFunc(pkg, NewDiFunc(pkg, file, "NewQueue"), decl))
+}
+
+func TestParser_CheckProfiles(t *testing.T) {
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	dev := &DiFunc{Name: "NewFakeMailer", ProviderId: "main.mailer", Profile: "dev", Package: pkg}
+	prod := &DiFunc{Name: "NewSmtpMailer", ProviderId: "main.mailer", Profile: "prod", Package: pkg,
+		Injectors: []*Injector{{Param: "smtp", ProviderId: "main.smtp"}}}
+	smtp := &DiFunc{Name: "NewSmtp", ProviderId: "main.smtp", Profile: "prod", Package: pkg}
+	app := &DiFunc{Name: "NewApp", ProviderId: "main.app", Package: pkg,
+		Injectors: []*Injector{{Param: "mailer", ProviderId: "main.mailer"}}}
+
+	parser := NewParser()
+	parser.Packages = []*DiPackage{pkg}
+	pkg.Funcs = DiFuncs{dev, prod, smtp, app}
+	assert.True(t, parser.checkInjectorLegal())
+	assert.True(t, parser.checkProfiles())
+
+	// The providers of all profiles are the dependencies
+	assert.Equal(t, dev, app.Injectors[0].Dependency)
+	assert.Equal(t, DiFuncs{prod}, app.Injectors[0].Profiles)
+	assert.True(t, parser.checkCyclicProvider())
+	assert.Equal(t, app, pkg.Funcs[len(pkg.Funcs)-1])
+
+	// The provider main.smtp is missing in profile dev
+	dev.Injectors = []*Injector{{Param: "smtp", ProviderId: "main.smtp"}}
+	assert.True(t, parser.checkInjectorLegal())
+	assert.False(t, parser.checkProfiles())
+}
+
+func TestParser_CheckLazyDependents(t *testing.T) {
+	pkg := NewDiPackage("example", "github.com/my/example", "/path/to/folder")
+	dev := &DiFunc{Name: "NewFakeMailer", ProviderId: "main.mailer", Profile: "dev", Lazy: true, Package: pkg}
+	prod := &DiFunc{Name: "NewSmtpMailer", ProviderId: "main.mailer", Profile: "prod", Lazy: true, Package: pkg}
+	app := &DiFunc{Name: "NewApp", ProviderId: "main.app", Package: pkg,
+		Injectors: []*Injector{{Param: "mailer", ProviderId: "main.mailer"}}}
+	server := &DiFunc{Name: "NewServer", ProviderId: "main.server", Package: pkg,
+		Injectors: []*Injector{{Param: "app", ProviderId: "main.app"}}}
+	req := &DiFunc{Name: "NewRequest", ProviderId: "main.req", Scope: "prototype", Package: pkg,
+		Injectors: []*Injector{{Param: "app", ProviderId: "main.app"}}}
+	db := &DiFunc{Name: "NewDb", ProviderId: "main.db", Package: pkg}
+	config := &DiFunc{Name: "DbConfig", ProviderId: "config.database", ConfigPrefix: "database", Lazy: true, Package: pkg}
+	repo := &DiFunc{Name: "NewRepo", ProviderId: "main.repo", Package: pkg,
+		Injectors: []*Injector{{Param: "config", ProviderId: "config.database"}}}
+	redis := &DiFunc{Name: "NewRedis", ProviderId: "main.redis", Package: pkg,
+		Injectors: []*Injector{{Param: "addr", Env: "REDIS_ADDR"}}}
+	cache := &DiFunc{Name: "NewCache", ProviderId: "main.cache", Package: pkg,
+		Injectors: []*Injector{{Param: "url", Key: "cache.url"}, {Param: "redis", ProviderId: "main.redis"}}}
+	store := &DiFunc{Name: "NewStore", ProviderId: "main.store", Package: pkg,
+		Injectors: []*Injector{{Param: "cache", ProviderId: "main.cache"}}}
+	memory := &DiFunc{Name: "NewMemoryQueue", ProviderId: "main.queue", OnMissing: true, Package: pkg}
+	worker := &DiFunc{Name: "NewWorker", ProviderId: "main.worker", Package: pkg,
+		Injectors: []*Injector{{Param: "queue", ProviderId: "main.queue"}}}
+
+	parser := NewParser()
+	parser.Packages = []*DiPackage{pkg}
+	pkg.Funcs = DiFuncs{dev, prod, app, server, req, db, config, repo, redis, cache, store, memory, worker}
+	assert.True(t, parser.checkInjectorLegal())
+	assert.True(t, parser.checkCyclicProvider())
+	assert.True(t, parser.checkLazyDependents())
+
+	// The singletons depending on a profile directly or transitively are created lazily
+	assert.True(t, app.Lazy)
+	assert.True(t, server.Lazy)
+	assert.False(t, req.Lazy)
+	assert.False(t, db.Lazy)
+
+	// So are the singletons depending on a provider bound to the configuration file
+	assert.True(t, repo.Lazy)
+
+	// So are the singletons injected with @value or @env, which are resolved after SetConfigSource in main(),
+	// and the singletons depending on them
+	assert.True(t, redis.Lazy)
+	assert.True(t, cache.Lazy)
+	assert.True(t, store.Lazy)
+
+	// So are the fallbacks, which yield to a provider registered later by a package generated separately,
+	// and the singletons depending on them
+	assert.True(t, memory.Lazy)
+	assert.True(t, worker.Lazy)
+
+	// A group member is created in init(), so it cannot be injected with @value or @env
+	member := &DiFunc{Name: "NewMember", GroupId: "members", Package: pkg,
+		Injectors: []*Injector{{Param: "name", Key: "member.name"}}}
+	pkg.Funcs = append(pkg.Funcs, member)
+	assert.False(t, parser.checkLazyDependents())
+	pkg.Funcs = pkg.Funcs[:len(pkg.Funcs)-1]
+
+	// A group member is created in init(), so it cannot depend on a profile
+	handler := &DiFunc{Name: "NewHandler", GroupId: "handlers", Package: pkg,
+		Injectors: []*Injector{{Param: "req", ProviderId: "main.req"}}}
+	pkg.Funcs = append(pkg.Funcs, handler)
+	assert.True(t, parser.checkInjectorLegal())
+	assert.False(t, parser.checkLazyDependents())
+}
+
+func TestParser_CheckLazyDependents_Packages(t *testing.T) {
+	app := NewDiPackage("app", "github.com/my/app", "/path/to/app")
+	router := NewDiPackage("router", "github.com/my/app/router", "/path/to/router")
+	controllers := NewDiPackage("controllers", "github.com/my/app/controllers", "/path/to/controllers")
+	app.Imports = map[string]bool{router.Path: true, controllers.Path: true}
+
+	// The controllers package imports the router package for the element type of the group
+	controllers.Imports = map[string]bool{router.Path: true}
+	ctrl := &DiFunc{Name: "NewUserController", GroupId: "controllers", Package: controllers}
+	controllers.Funcs = DiFuncs{ctrl}
+	server := &DiFunc{Name: "NewServer", ProviderId: "router.server", Package: router,
+		Injectors: []*Injector{{Param: "ctrls", Group: "controllers", Members: DiFuncs{ctrl}}}}
+	router.Funcs = DiFuncs{server}
+	newApp := &DiFunc{Name: "NewApp", ProviderId: "main.app", Package: app,
+		Injectors: []*Injector{{Param: "ctrls", Group: "controllers", Members: DiFuncs{ctrl}}}}
+	app.Funcs = DiFuncs{newApp}
+
+	parser := NewParser()
+	parser.Packages = []*DiPackage{app, router, controllers}
+	assert.True(t, parser.checkLazyDependents())
+
+	// The router package does not import the controllers package, which may be initialized after it
+	assert.True(t, server.Lazy)
+	assert.False(t, newApp.Lazy)
+
+	// A group member cannot depend on the provider of a package which is not imported
+	handler := &DiFunc{Name: "NewHandler", GroupId: "handlers", Package: router,
+		Injectors: []*Injector{{Param: "ctrl", ProviderId: "controllers.user", Dependency: ctrl}}}
+	router.Funcs = append(router.Funcs, handler)
+	assert.False(t, parser.checkLazyDependents())
+}
+
+func TestParser_ParseProvider_Conditional(t *testing.T) {
+	parser := NewParser()
+	pkg := NewDiPackage("example", "/path/to/example", "/path/to/folder")
+	file := NewDiFile(pkg, "example.go")
+
+	redis := NewDiFunc(pkg, file, "NewRedisCache")
+	assert.NoError(t, parser.parseProvider("{\"id\":\"main.cache\", \"conditionalOn\":\"main.redis\"}", redis))
+	assert.Equal(t, "main.redis", redis.ConditionalOn)
+	pkg.Funcs = append(pkg.Funcs, redis)
+
+	// The conditional providers can share the ID, they are checked after the conditions are resolved
+	memory := NewDiFunc(pkg, file, "NewMemoryCache")
+	assert.NoError(t, parser.parseProvider("{\"id\":\"main.cache\", \"onMissing\":true}", memory))
+	assert.True(t, memory.OnMissing)
+
+	err := parser.parseProvider("{\"id\":\"main.cache\", \"onMissing\":true, \"profile\":\"dev\"}", NewDiFunc(pkg, file, "NewCache"))
+	assert.EqualError(t, err, "conditional provider main.cache cannot have a profile")
+	err = parser.parseProvider("{\"id\":\"main.cache\", \"conditionalOn\":\"main.cache\"}", NewDiFunc(pkg, file, "NewCache"))
+	assert.EqualError(t, err, "provider main.cache cannot be conditional on itself")
+}
+
+func TestParser_ResolveConditions(t *testing.T) {
+	app := NewDiPackage("app", "github.com/my/app", "/path/to/app")
+	lib := NewDiPackage("lib", "github.com/my/lib", "/path/to/lib")
+	newParser := func() *Parser {
+		parser := NewParser()
+		parser.Packages = []*DiPackage{app, lib}
+		return parser
+	}
+
+	memory := &DiFunc{Name: "NewMemoryCache", ProviderId: "main.cache", OnMissing: true, Package: lib}
+	redisCache := &DiFunc{Name: "NewRedisCache", ProviderId: "main.cache", ConditionalOn: "main.redis", Package: lib}
+	metrics := &DiFunc{Name: "NewCacheMetrics", ProviderId: "main.metrics", ConditionalOn: "main.cache", Package: lib}
+	redis := &DiFunc{Name: "NewRedis", ProviderId: "main.redis", Package: app}
+
+	// The fallback is removed since the redis cache is registered
+	app.Funcs = DiFuncs{redis}
+	lib.Funcs = DiFuncs{memory, redisCache, metrics}
+	assert.True(t, newParser().resolveConditions())
+	assert.Equal(t, DiFuncs{redisCache, metrics}, lib.Funcs)
+
+	// The fallback is registered without redis, so the metrics are registered as well
+	app.Funcs = DiFuncs{}
+	lib.Funcs = DiFuncs{memory, redisCache, metrics}
+	assert.True(t, newParser().resolveConditions())
+	assert.Equal(t, DiFuncs{memory, metrics}, lib.Funcs)
+
+	// The conditions are resolved repeatedly, the metrics are removed together with the redis cache they depend on
+	lib.Funcs = DiFuncs{redisCache, metrics}
+	assert.True(t, newParser().resolveConditions())
+	assert.Empty(t, lib.Funcs)
+
+	// Two fallbacks of the same ID cannot be registered together
+	other := &DiFunc{Name: "NewOtherCache", ProviderId: "main.cache", OnMissing: true, Package: app}
+	app.Funcs = DiFuncs{other}
+	lib.Funcs = DiFuncs{memory}
+	assert.False(t, newParser().resolveConditions())
+}
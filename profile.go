@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"fmt"
+	"os"
+)
+
+// ProfileEnv is the environment variable which selects the active profile unless it is set by SetProfile.
+// ProfileEnv 是选择当前激活的profile的环境变量，除非通过SetProfile设置了profile
+const ProfileEnv = "DIGO_PROFILE"
+
+// profiled represents the registration of the providers of a profile, e.g. a fake and a real implementation of an ID.
+// profiled 表示一个profile的provider的注册函数，比如同一个ID的模拟实现和真实实现
+type profiled struct {
+	profile  string
+	register func(c *Container)
+}
+
+// SetProfile sets the active profile of the container, which selects the providers registered by RegisterProfile.
+// The profile is activated the first time an ID which is not registered yet is looked up after a profile is selected,
+// so it must be set before that, otherwise an error is returned. Without SetProfile, the profile is the environment
+// variable DIGO_PROFILE.
+// SetProfile 设置容器当前激活的profile，它决定了哪些通过RegisterProfile注册的provider会生效。
+// profile在选择了profile之后第一次查找尚未注册的ID的时候激活，所以必须在此之前设置，否则返回错误。
+// 如果没有调用SetProfile，则使用环境变量DIGO_PROFILE
+func (c *Container) SetProfile(profile string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.activated {
+		return fmt.Errorf("cannot set profile %s, profile %q is already active", profile, c.profile)
+	}
+	c.profile = profile
+	c.profileSet = true
+	return nil
+}
+
+// Profile returns the active profile of the container. A child scope has the profile of its parent.
+// Profile 返回容器当前激活的profile，子作用域使用父容器的profile
+func (c *Container) Profile() string {
+	if c.parent != nil {
+		return c.parent.Profile()
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.profileSet || c.activated {
+		return c.profile
+	}
+	return os.Getenv(ProfileEnv)
+}
+
+// RegisterProfile registers the providers of the profile by calling the register function, only if the profile
+// is the active profile of the container. The registration is deferred until the profile is activated,
+// so the profile can be set in main() after the generated init() functions have run.
+// RegisterProfile 只有当profile是容器当前激活的profile时，才会调用register函数注册该profile的provider。
+// 注册会被推迟到profile激活的时候，所以可以在生成的init()函数执行之后，在main()中设置profile
+func (c *Container) RegisterProfile(profile string, register func(c *Container)) {
+	c.mu.Lock()
+	if !c.activated {
+		c.pending = append(c.pending, profiled{profile: profile, register: register})
+		c.mu.Unlock()
+		return
+	}
+	active := c.profile
+	c.mu.Unlock()
+
+	if profile == active {
+		register(c)
+	}
+}
+
+// activate activates the profile of the container exactly once, and registers the providers of the active profile.
+// Concurrent callers wait until the providers are registered. Nothing is activated until a profile is selected
+// by SetProfile or DIGO_PROFILE, since no provider of a profile would be registered, so the lookups of the missing
+// IDs in init(), such as the optional ones, do not prevent main() from setting the profile.
+// activate 激活容器的profile，只会执行一次，并注册当前激活的profile的provider，并发的调用者会等待注册完成。
+// 在通过SetProfile或者DIGO_PROFILE选择profile之前不会激活，因为此时没有任何profile的provider需要注册，
+// 所以init()中对不存在的ID的查找，比如可选的查找，不会妨碍main()设置profile
+func (c *Container) activate() {
+	if len(c.Profile()) == 0 {
+		return
+	}
+	c.activation.Do(func() {
+		profile := c.Profile()
+		c.mu.Lock()
+		c.activated = true
+		c.profile = profile
+		pending := c.pending
+		c.pending = nil
+
+		// The dependencies of the providers of the profile described beforehand are recorded now.
+		// 现在记录之前描述的该profile的provider的依赖
+		for _, meta := range c.metas {
+			if meta.Profile == profile {
+				c.dependOn(meta)
+			}
+		}
+		c.mu.Unlock()
+
+		for _, p := range pending {
+			if p.profile == profile {
+				p.register(c)
+			}
+		}
+	})
+}
+
+// SetProfile sets the active profile of the default container.
+func SetProfile(profile string) error {
+	return defaultContainer.SetProfile(profile)
+}
+
+// Profile returns the active profile of the default container.
+func Profile() string {
+	return defaultContainer.Profile()
+}
+
+// RegisterProfile registers the providers of the profile into the default container if the profile is active.
+func RegisterProfile(profile string, register func(c *Container)) {
+	defaultContainer.RegisterProfile(profile, register)
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func registerMailers(c *Container) {
+	c.RegisterProfile("dev", func(c *Container) {
+		c.RegisterSingleton("main.mailer", "fake mailer")
+	})
+	c.RegisterProfile("prod", func(c *Container) {
+		c.RegisterSingleton("main.mailer", "smtp mailer")
+	})
+}
+
+func TestRegisterProfile(t *testing.T) {
+	t.Setenv(ProfileEnv, "prod")
+
+	// The profile can be set after the registration
+	c := NewContainer()
+	registerMailers(c)
+	assert.Equal(t, "prod", c.Profile())
+	assert.NoError(t, c.SetProfile("dev"))
+	assert.Equal(t, "dev", c.Profile())
+
+	mailer, err := c.Provide("main.mailer")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake mailer", mailer)
+
+	// The child scopes use the profile of their parent
+	scoped, err := c.NewScope().Provide("main.mailer")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake mailer", scoped)
+
+	// The profile cannot be changed once it is active
+	assert.Error(t, c.SetProfile("prod"))
+
+	// The profiles registered after the activation are registered immediately if they are active
+	c.RegisterProfile("dev", func(c *Container) {
+		c.RegisterSingleton("main.cache", "fake cache")
+	})
+	c.RegisterProfile("prod", func(c *Container) {
+		c.RegisterSingleton("main.queue", "kafka")
+	})
+	cache, err := c.Provide("main.cache")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake cache", cache)
+	_, err = c.Provide("main.queue")
+	assert.True(t, errors.Is(err, ErrProviderNotFound))
+}
+
+func TestRegisterProfile_EagerDependents(t *testing.T) {
+	t.Setenv(ProfileEnv, "")
+
+	// Like the generated init(), an eager singleton is created from a provider without a profile,
+	// and the singleton depending on a profile is lazy.
+	c := NewContainer()
+	registerMailers(c)
+	assert.NoError(t, c.RegisterSingleton("main.db", "db"))
+	db, err := c.Provide("main.db")
+	assert.NoError(t, err)
+	assert.NoError(t, c.RegisterSingleton("main.repo", db.(string)+" repo"))
+	assert.NoError(t, c.RegisterLazy("main.app", func(c *Container) (any, error) {
+		return ProvideFrom[string](c, "main.mailer")
+	}))
+	assert.NoError(t, c.Init())
+
+	// The profile is not active until an ID which is not registered is looked up
+	assert.NoError(t, c.SetProfile("dev"))
+	app, err := c.Provide("main.app")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake mailer", app)
+	assert.Error(t, c.SetProfile("prod"))
+}
+
+func TestRegisterProfile_OptionalMiss(t *testing.T) {
+	t.Setenv(ProfileEnv, "")
+
+	// Like the generated init(), an eager singleton looks up an optional ID which is missing
+	c := NewContainer()
+	registerMailers(c)
+	cache, err := ProvideOptionalFrom[string](c, "main.cache")
+	assert.NoError(t, err)
+	assert.Empty(t, cache)
+	_, err = c.Provide("main.missing")
+	assert.True(t, errors.Is(err, ErrProviderNotFound))
+
+	// The profile is not activated before it is selected
+	assert.NoError(t, c.SetProfile("dev"))
+	mailer, err := ProvideOptionalFrom[string](c, "main.mailer")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake mailer", mailer)
+	assert.Error(t, c.SetProfile("prod"))
+}
+
+func TestRegisterProfile_Registered(t *testing.T) {
+	t.Setenv(ProfileEnv, "prod")
+
+	// Like the generated Register(c), the providers of the profiles are described before they are registered
+	c := NewContainer()
+	c.Describe(Metadata{Id: "main.smtp", Scope: ScopeSingleton, Profile: "prod"})
+	c.RegisterProfile("prod", func(c *Container) {
+		c.RegisterSingleton("main.smtp", "smtp")
+	})
+	c.Describe(Metadata{Id: "main.mailer", Scope: ScopeSingleton, Profile: "dev"})
+	c.Describe(Metadata{Id: "main.mailer", Scope: ScopeSingleton, Profile: "prod", Dependencies: []string{"main.smtp"}})
+	registerMailers(c)
+	c.Describe(Metadata{Id: "main.app", Scope: ScopeSingleton})
+	assert.NoError(t, c.RegisterSingleton("main.app", "app"))
+
+	// Listing the providers does not activate the profile, the providers of the profiles are pending
+	assert.Equal(t, []Metadata{{Id: "main.app", Scope: ScopeSingleton, ConcreteType: "string"}}, c.Registered())
+	assert.Len(t, c.Pending(), 3)
+	assert.NoError(t, c.SetProfile("prod"))
+
+	mailer, err := c.Provide("main.mailer")
+	assert.NoError(t, err)
+	assert.Equal(t, "smtp mailer", mailer)
+	assert.Empty(t, c.Pending())
+	assert.Equal(t, []Metadata{
+		{Id: "main.smtp", Scope: ScopeSingleton, Profile: "prod", ConcreteType: "string"},
+		{Id: "main.mailer", Scope: ScopeSingleton, Profile: "prod", Dependencies: []string{"main.smtp"}, ConcreteType: "string"},
+		{Id: "main.app", Scope: ScopeSingleton, ConcreteType: "string"},
+	}, c.Registered())
+
+	// Only the dependencies of the active profile are recorded
+	assert.Equal(t, []string{"main.smtp"}, c.deps["main.mailer"])
+}
+
+func TestRegisterProfile_Env(t *testing.T) {
+	t.Setenv(ProfileEnv, "prod")
+	c := NewContainer()
+	registerMailers(c)
+
+	mailer, err := c.Provide("main.mailer")
+	assert.NoError(t, err)
+	assert.Equal(t, "smtp mailer", mailer)
+	assert.Len(t, c.Registered(), 1)
+
+	// No provider of a profile is registered without an active profile
+	t.Setenv(ProfileEnv, "")
+	c = NewContainer()
+	registerMailers(c)
+	_, err = c.Provide("main.mailer")
+	assert.True(t, errors.Is(err, ErrProviderNotFound))
+}
+
+func TestRegisterProfile_Replace(t *testing.T) {
+	c := NewContainer()
+	registerMailers(c)
+	assert.NoError(t, c.SetProfile("prod"))
+
+	// The provider of the active profile is registered before it is replaced, so it does not conflict later
+	restore := c.Replace("main.mailer", "test mailer")
+	mailer, err := c.Provide("main.mailer")
+	assert.NoError(t, err)
+	assert.Equal(t, "test mailer", mailer)
+	assert.NoError(t, c.Init())
+
+	restore()
+	mailer, err = c.Provide("main.mailer")
+	assert.NoError(t, err)
+	assert.Equal(t, "smtp mailer", mailer)
+}
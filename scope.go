@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+// NewScope creates a child scope of the container, e.g. for an HTTP request.
+// The IDs and groups unknown to the scope are resolved through the container, singletons are shared with the container,
+// while the request scoped providers create one object for each scope.
+// NewScope 创建容器的一个子作用域，比如每个HTTP请求一个作用域，
+// 子作用域中找不到的id和组会从父容器中查找，单例和父容器共享，而请求作用域的provider会为每个子作用域创建一个对象
+func (c *Container) NewScope() *Container {
+	scope := NewContainer()
+	scope.parent = c
+	return scope
+}
+
+// RegisterScoped registers a request scoped provider with the provided ID. The factory is called once for each
+// child scope created by NewScope, the first time the provider is provided from that scope.
+// RegisterScoped 注册一个请求作用域的provider，每个通过NewScope创建的子作用域中第一次获取该provider时，
+// 都会调用一次factory
+// It returns a *DuplicateError if the ID is already registered.
+func (c *Container) RegisterScoped(id string, factory Factory) error {
+	return c.register(&entry{id: id, scope: ScopeRequest, factory: factory, pkg: callerPackage(1)})
+}
+
+// scoped returns the provider of the scope for the request scoped provider p registered into a parent,
+// the provider is created on the first call.
+// scoped 返回父容器中注册的请求作用域provider在当前作用域中对应的provider，第一次调用时创建
+func (c *Container) scoped(p *entry) *entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if local, ok := c.providers[p.id]; ok {
+		return local
+	}
+	local := &entry{id: p.id, scope: ScopeRequest, factory: p.factory}
+	c.providers[p.id] = local
+	return local
+}
+
+// NewScope creates a child scope of the default container.
+func NewScope() *Container {
+	return defaultContainer.NewScope()
+}
+
+// RegisterScoped registers a request scoped provider with the provided ID into the default container.
+func RegisterScoped(id string, factory Factory) error {
+	return defaultContainer.register(&entry{id: id, scope: ScopeRequest, factory: factory, pkg: callerPackage(1)})
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTx struct {
+	db     string
+	closed *[]string
+	name   string
+}
+
+func (tx *testTx) Close() error {
+	*tx.closed = append(*tx.closed, tx.name)
+	if tx.name == "broken" {
+		return errors.New("broken pipe")
+	}
+	return nil
+}
+
+func TestScope_Request(t *testing.T) {
+	c := NewContainer()
+	closed := make([]string, 0)
+	count := 0
+
+	c.RegisterSingleton("main.db", "db")
+	c.RegisterScoped("main.tx", func(c *Container) (any, error) {
+		db, err := ProvideFrom[string](c, "main.db")
+		if err != nil {
+			return nil, err
+		}
+		count++
+		return &testTx{db: db, closed: &closed, name: "tx"}, nil
+	})
+
+	// Request scoped providers cannot be provided from the root container
+	_, err := c.Provide("main.tx")
+	assert.EqualError(t, err, "main.tx is request scoped and must be provided from a scope created by NewScope")
+
+	scope1 := c.NewScope()
+	scope2 := c.NewScope()
+
+	// Each scope gets its own object, which is reused within the scope
+	tx1, err := ProvideFrom[*testTx](scope1, "main.tx")
+	assert.NoError(t, err)
+	assert.Equal(t, "db", tx1.db)
+	again, err := ProvideFrom[*testTx](scope1, "main.tx")
+	assert.NoError(t, err)
+	assert.Same(t, tx1, again)
+	tx2, err := ProvideFrom[*testTx](scope2, "main.tx")
+	assert.NoError(t, err)
+	assert.NotSame(t, tx1, tx2)
+	assert.Equal(t, 2, count)
+
+	// Singletons are resolved through the parent
+	db, err := scope1.Provide("main.db")
+	assert.NoError(t, err)
+	assert.Equal(t, "db", db)
+
+	// Closing a scope disposes its instances only
+	assert.NoError(t, scope1.Close(context.Background()))
+	assert.Equal(t, []string{"tx"}, closed)
+	_, err = scope1.Provide("main.tx")
+	assert.EqualError(t, err, "container is closed")
+	_, err = scope2.Provide("main.db")
+	assert.NoError(t, err)
+}
+
+func TestScope_Singleton(t *testing.T) {
+	c := NewContainer()
+	c.RegisterScoped("main.tx", func(c *Container) (any, error) {
+		return "tx", nil
+	})
+
+	// A singleton is created by the container it is registered into, so it cannot capture request scoped objects
+	c.RegisterLazy("main.service", func(c *Container) (any, error) {
+		return c.Provide("main.tx")
+	})
+	_, err := c.NewScope().Provide("main.service")
+	assert.Error(t, err)
+
+	// A prototype is created by the scope it is provided from
+	c.RegisterPrototype("main.handler", func(c *Container) (any, error) {
+		return c.Provide("main.tx")
+	})
+	handler, err := c.NewScope().Provide("main.handler")
+	assert.NoError(t, err)
+	assert.Equal(t, "tx", handler)
+}
+
+func TestScope_Members(t *testing.T) {
+	c := NewContainer()
+	c.RegisterMember("controllers", "user")
+
+	scope := c.NewScope()
+	members, err := scope.Members("controllers")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"user"}, members)
+
+	// Groups registered into the scope take precedence over the parent
+	scope.RegisterMember("controllers", "request")
+	members, err = scope.Members("controllers")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"request"}, members)
+}
+
+func TestScope_CloseOrder(t *testing.T) {
+	c := NewContainer()
+	closed := make([]string, 0)
+
+	for _, name := range []string{"first", "broken", "last"} {
+		name := name
+		c.RegisterScoped(name, func(c *Container) (any, error) {
+			return &testTx{closed: &closed, name: name}, nil
+		})
+	}
+
+	scope := c.NewScope()
+	for _, name := range []string{"first", "broken", "last"} {
+		_, err := scope.Provide(name)
+		assert.NoError(t, err)
+	}
+
+	// Objects are closed in the reverse order of their creation, and the errors are returned
+	err := scope.Close(context.Background())
+	assert.EqualError(t, err, "failed to close broken: broken pipe")
+	assert.Equal(t, []string{"last", "broken", "first"}, closed)
+
+	// Closing again is a no-op
+	assert.NoError(t, scope.Close(context.Background()))
+}
@@ -0,0 +1,44 @@
+
+//
+// This file is generated by digogen. Run 'digogen' to regenerate.
+//
+// You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
+package main
+
+import (
+	"github.com/werbenhu/digo/testdata/fallback/lib"
+	"github.com/werbenhu/digo"
+)
+
+// init_fallback_cache registers the singleton object with ID fallback.cache into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("fallback.cache")`.
+// The obj is of type `any`, use `digo.ProvideFrom[lib.Cache](c, "fallback.cache")` to retrieve it as its actual type.
+// The typed getter ProvideFallbackCache() retrieves it from the default container as well.
+func init_fallback_cache(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "fallback.cache", Type: "lib.Cache", Constructor: "NewRedisCache", Package: "github.com/werbenhu/digo/testdata/fallback/app", Scope: "singleton"})
+	fallback_cache_obj := NewRedisCache()
+	c.RegisterSingleton("fallback.cache", fallback_cache_obj)
+}
+
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	init_fallback_cache(c)
+}
+
+// init registers all providers in the current package into the default container.
+func init() {
+	Register(digo.Default())
+}
+
+// ProvideFallbackCache returns the singleton object with ID fallback.cache.
+// It panics if the object cannot be provided, use `digo.ProvideAs[lib.Cache]("fallback.cache")` to handle the error instead.
+func ProvideFallbackCache() lib.Cache {
+	obj, err := digo.ProvideAs[lib.Cache]("fallback.cache")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
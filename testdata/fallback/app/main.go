@@ -0,0 +1,29 @@
+// Package main provides the cache of the lib package, whose code is generated separately,
+// so the fallback of the lib package is registered as well and yields to it at runtime.
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/werbenhu/digo"
+	"github.com/werbenhu/digo/testdata/fallback/lib"
+)
+
+type RedisCache struct{}
+
+func (c *RedisCache) Name() string {
+	return "redis cache"
+}
+
+// @provider({"id":"fallback.cache"})
+func NewRedisCache() lib.Cache {
+	return &RedisCache{}
+}
+
+func main() {
+	if err := digo.Init(); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(lib.ProvideFallbackStore().Cache.Name())
+}
@@ -0,0 +1,70 @@
+
+//
+// This file is generated by digogen. Run 'digogen' to regenerate.
+//
+// You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
+package lib
+
+import "github.com/werbenhu/digo"
+
+// init_fallback_cache registers the fallback with ID fallback.cache into the container c
+// It yields to any other provider of the ID, even one registered by a package generated separately.
+// Now you can retrieve the object by using `obj, err := c.Provide("fallback.cache")`.
+// The obj is of type `any`, use `digo.ProvideFrom[Cache](c, "fallback.cache")` to retrieve it as its actual type.
+// The typed getter ProvideFallbackCache() retrieves it from the default container as well.
+func init_fallback_cache(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "fallback.cache", Type: "Cache", Constructor: "NewMemoryCache", Package: "github.com/werbenhu/digo/testdata/fallback/lib", Scope: "singleton", Lazy: true, Fallback: true})
+	c.RegisterFallback("fallback.cache", "singleton", func(c *digo.Container) (any, error) {
+		return NewMemoryCache(), nil
+	})
+}
+
+// init_fallback_store registers the lazy singleton object with ID fallback.store into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("fallback.store")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Store](c, "fallback.store")` to retrieve it as its actual type.
+// The typed getter ProvideFallbackStore() retrieves it from the default container as well.
+func init_fallback_store(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "fallback.store", Type: "*Store", Constructor: "NewStore", Package: "github.com/werbenhu/digo/testdata/fallback/lib", Scope: "singleton", Lazy: true, Dependencies: []string{"fallback.cache"}})
+	c.RegisterLazy("fallback.store", func(c *digo.Container) (any, error) {
+		cache_dep, err := digo.ProvideFrom[Cache](c, "fallback.cache")
+		if err != nil {
+			return nil, err
+		}
+		return NewStore(cache_dep), nil
+	})
+}
+
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	init_fallback_cache(c)
+	init_fallback_store(c)
+}
+
+// init registers all providers in the current package into the default container.
+func init() {
+	Register(digo.Default())
+}
+
+// ProvideFallbackCache returns the singleton object with ID fallback.cache.
+// It panics if the object cannot be provided, use `digo.ProvideAs[Cache]("fallback.cache")` to handle the error instead.
+func ProvideFallbackCache() Cache {
+	obj, err := digo.ProvideAs[Cache]("fallback.cache")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideFallbackStore returns the singleton object with ID fallback.store.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Store]("fallback.store")` to handle the error instead.
+func ProvideFallbackStore() *Store {
+	obj, err := digo.ProvideAs[*Store]("fallback.store")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
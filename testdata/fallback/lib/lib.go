@@ -0,0 +1,27 @@
+// Package lib declares a fallback cache, whose code is generated separately from the app package overriding it.
+package lib
+
+type Cache interface {
+	Name() string
+}
+
+type MemoryCache struct{}
+
+func (c *MemoryCache) Name() string {
+	return "memory cache"
+}
+
+// @provider({"id":"fallback.cache", "onMissing":true})
+func NewMemoryCache() Cache {
+	return &MemoryCache{}
+}
+
+type Store struct {
+	Cache Cache
+}
+
+// @provider({"id":"fallback.store"})
+// @inject({"param":"cache", "id":"fallback.cache"})
+func NewStore(cache Cache) *Store {
+	return &Store{Cache: cache}
+}
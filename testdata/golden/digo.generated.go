@@ -0,0 +1,234 @@
+
+//
+// This file is generated by digogen. Run 'digogen' to regenerate.
+//
+// You can install this tool by running `go install github.com/werbenhu/digo/digogen`.
+// For more details, please refer to https://github.com/werbenhu/digo. 
+//
+package golden
+
+import "github.com/werbenhu/digo"
+
+// init_golden_config registers the singleton object with ID golden.config into the container c
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("golden.config")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Config](c, "golden.config")` to retrieve it as its actual type.
+// The typed getter ProvideGoldenConfig() retrieves it from the default container as well.
+func init_golden_config(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "golden.config", Type: "*Config", Constructor: "NewConfig", Package: "github.com/werbenhu/digo/testdata/golden", Scope: "singleton"})
+	golden_config_obj := NewConfig()
+	c.RegisterSingleton("golden.config", golden_config_obj)
+}
+
+// init_golden_db registers the lazy singleton object with ID golden.db into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("golden.db")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Db](c, "golden.db")` to retrieve it as its actual type.
+// The typed getter ProvideGoldenDb() retrieves it from the default container as well.
+func init_golden_db(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "golden.db", Type: "*Db", Constructor: "NewDb", Package: "github.com/werbenhu/digo/testdata/golden", Scope: "singleton", Lazy: true, Dependencies: []string{"golden.config"}})
+	c.RegisterLazy("golden.db", func(c *digo.Container) (any, error) {
+		c_dep, err := digo.ProvideFrom[*Config](c, "golden.config")
+		if err != nil {
+			return nil, err
+		}
+		url_dep, err := digo.ValueFrom[string](c, "db.url", "localhost:3306")
+		if err != nil {
+			return nil, err
+		}
+		return NewDb(c_dep, url_dep)
+	})
+}
+
+// init_golden_mailer_dev registers the lazy singleton object with ID golden.mailer into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("golden.mailer")`.
+// The obj is of type `any`, use `digo.ProvideFrom[Mailer](c, "golden.mailer")` to retrieve it as its actual type.
+// The typed getter ProvideGoldenMailer() retrieves it from the default container as well.
+func init_golden_mailer_dev(c *digo.Container) {
+	c.RegisterLazy("golden.mailer", func(c *digo.Container) (any, error) {
+		return NewFakeMailer(), nil
+	})
+}
+
+// init_golden_mailer_prod registers the lazy singleton object with ID golden.mailer into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("golden.mailer")`.
+// The obj is of type `any`, use `digo.ProvideFrom[Mailer](c, "golden.mailer")` to retrieve it as its actual type.
+// The typed getter ProvideGoldenMailer() retrieves it from the default container as well.
+func init_golden_mailer_prod(c *digo.Container) {
+	c.RegisterLazy("golden.mailer", func(c *digo.Container) (any, error) {
+		obj_dep, err := digo.ProvideFrom[*Config](c, "golden.config")
+		if err != nil {
+			return nil, err
+		}
+		return NewSmtpMailer(obj_dep), nil
+	})
+}
+
+// init_golden_cache registers the lazy singleton object with ID golden.cache into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("golden.cache")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Db](c, "golden.cache")` to retrieve it as its actual type.
+// The typed getter ProvideGoldenCache() retrieves it from the default container as well.
+func init_golden_cache(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "golden.cache", Type: "*Db", Constructor: "NewCache", Package: "github.com/werbenhu/digo/testdata/golden", Scope: "singleton", Lazy: true, Dependencies: []string{"golden.db"}})
+	c.RegisterLazy("golden.cache", func(c *digo.Container) (any, error) {
+		digo_dep, err := digo.ProvideFrom[*Db](c, "golden.db")
+		if err != nil {
+			return nil, err
+		}
+		return NewCache(digo_dep), nil
+	})
+}
+
+// init_golden_request registers the prototype with ID golden.request into the container c
+// A new object is created every time it is provided.
+// Now you can retrieve a new object by using `obj, err := c.Provide("golden.request")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Request](c, "golden.request")` to retrieve it as its actual type.
+// The typed getter ProvideGoldenRequest() retrieves it from the default container as well.
+func init_golden_request(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "golden.request", Type: "*Request", Constructor: "NewRequest", Package: "github.com/werbenhu/digo/testdata/golden", Scope: "prototype", Dependencies: []string{"golden.db"}})
+	c.RegisterPrototype("golden.request", func(c *digo.Container) (any, error) {
+		env_vars := digo.NewEnvironment("NewRequest")
+		env_vars_dep := digo.LookupEnv[string](env_vars, "GOLDEN_ENV", false, "dev")
+		err := env_vars.Err()
+		if err != nil {
+			return nil, err
+		}
+		err_dep, err := digo.ProvideFrom[*Db](c, "golden.db")
+		if err != nil {
+			return nil, err
+		}
+		return NewRequest(env_vars_dep, err_dep), nil
+	})
+}
+
+// init_golden_router registers the lazy singleton object with ID golden.router into the container c
+// The object and the lazy objects it depends on are created on the first time it is provided.
+// Now you can retrieve the singleton object by using `obj, err := c.Provide("golden.router")`.
+// The obj is of type `any`, use `digo.ProvideFrom[*Router](c, "golden.router")` to retrieve it as its actual type.
+// The typed getter ProvideGoldenRouter() retrieves it from the default container as well.
+func init_golden_router(c *digo.Container) {
+	c.Describe(digo.Metadata{Id: "golden.router", Type: "*Router", Constructor: "NewRouter", Package: "github.com/werbenhu/digo/testdata/golden", Scope: "singleton", Lazy: true, Dependencies: []string{"golden.missing", "golden.mailer"}, GroupDependencies: []string{"golden.handlers"}})
+	c.RegisterLazy("golden.router", func(c *digo.Container) (any, error) {
+		c_dep, err := digo.MembersFrom[Handler](c, "golden.handlers")
+		if err != nil {
+			return nil, err
+		}
+		member_dep, err := digo.ProvideOptionalFrom[*Db](c, "golden.missing")
+		if err != nil {
+			return nil, err
+		}
+		mailer_dep, err := digo.ProvideFrom[Mailer](c, "golden.mailer")
+		if err != nil {
+			return nil, err
+		}
+		return NewRouter(c_dep, member_dep, mailer_dep), nil
+	})
+}
+
+// Add a member object to group golden.handlers of the container c
+// Now you can retrieve the group's member objects by using `objs, err := c.Members("golden.handlers")`.
+// The member object is named user, you can also retrieve it by using `objs, err := c.MemberMap("golden.handlers")`.
+// The objs are of type `[]any`, use `digo.MembersFrom[Handler](c, "golden.handlers")` to retrieve them as a typed slice.
+// The typed getter MembersGoldenHandlers() retrieves them from the default container as well.
+func group_golden_handlers_NewUserHandler(c *digo.Container) {
+	c.Describe(digo.Metadata{Type: "Handler", Constructor: "NewUserHandler", Package: "github.com/werbenhu/digo/testdata/golden", Dependencies: []string{"golden.config"}, Groups: []string{"golden.handlers"}, Name: "user"})
+	member_dep, err := digo.ProvideFrom[*Config](c, "golden.config")
+	if err != nil {
+		c.FailMember("golden.handlers", "NewUserHandler", err)
+		return
+	}
+	member := NewUserHandler(member_dep)
+	c.RegisterMemberWith("golden.handlers", member, digo.MemberOptions{Name: "user", Source: "github.com/werbenhu/digo/testdata/golden/golden.go", Line: 83})
+}
+
+// Register registers all providers in the current package into the container c.
+// The providers of other packages that they depend on must be registered into c beforehand.
+func Register(c *digo.Container) {
+	init_golden_config(c)
+	init_golden_db(c)
+	c.Describe(digo.Metadata{Id: "golden.mailer", Type: "Mailer", Constructor: "NewFakeMailer", Package: "github.com/werbenhu/digo/testdata/golden", Scope: "singleton", Lazy: true, Profile: "dev"})
+	c.RegisterProfile("dev", init_golden_mailer_dev)
+	c.Describe(digo.Metadata{Id: "golden.mailer", Type: "Mailer", Constructor: "NewSmtpMailer", Package: "github.com/werbenhu/digo/testdata/golden", Scope: "singleton", Lazy: true, Profile: "prod", Dependencies: []string{"golden.config"}})
+	c.RegisterProfile("prod", init_golden_mailer_prod)
+	group_golden_handlers_NewUserHandler(c)
+	init_golden_cache(c)
+	init_golden_request(c)
+	init_golden_router(c)
+}
+
+// init registers all providers in the current package into the default container.
+func init() {
+	Register(digo.Default())
+}
+
+// ProvideGoldenConfig returns the singleton object with ID golden.config.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Config]("golden.config")` to handle the error instead.
+func ProvideGoldenConfig() *Config {
+	obj, err := digo.ProvideAs[*Config]("golden.config")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideGoldenDb returns the singleton object with ID golden.db.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Db]("golden.db")` to handle the error instead.
+func ProvideGoldenDb() *Db {
+	obj, err := digo.ProvideAs[*Db]("golden.db")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideGoldenMailer returns the singleton object with ID golden.mailer.
+// It panics if the object cannot be provided, use `digo.ProvideAs[Mailer]("golden.mailer")` to handle the error instead.
+func ProvideGoldenMailer() Mailer {
+	obj, err := digo.ProvideAs[Mailer]("golden.mailer")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideGoldenCache returns the singleton object with ID golden.cache.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Db]("golden.cache")` to handle the error instead.
+func ProvideGoldenCache() *Db {
+	obj, err := digo.ProvideAs[*Db]("golden.cache")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideGoldenRequest returns a new object with ID golden.request.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Request]("golden.request")` to handle the error instead.
+func ProvideGoldenRequest() *Request {
+	obj, err := digo.ProvideAs[*Request]("golden.request")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// ProvideGoldenRouter returns the singleton object with ID golden.router.
+// It panics if the object cannot be provided, use `digo.ProvideAs[*Router]("golden.router")` to handle the error instead.
+func ProvideGoldenRouter() *Router {
+	obj, err := digo.ProvideAs[*Router]("golden.router")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// MembersGoldenHandlers returns the member objects of group golden.handlers.
+// It panics if the members cannot be provided, use `digo.MembersOf[Handler]("golden.handlers")` to handle the error instead.
+func MembersGoldenHandlers() []Handler {
+	obj, err := digo.MembersOf[Handler]("golden.handlers")
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
@@ -0,0 +1,99 @@
+// Package golden declares the providers whose generated code is compared with digo.generated.go and compiled
+// by TestGenerator_Golden. The parameters are named like the variables of the generated code on purpose.
+package golden
+
+import "errors"
+
+type Config struct {
+	Name string
+}
+
+// @provider({"id":"golden.config"})
+func NewConfig() *Config {
+	return &Config{Name: "golden"}
+}
+
+type Db struct {
+	Url string
+}
+
+// @provider({"id":"golden.db"})
+// @inject({"param":"c", "id":"golden.config"})
+// @value({"param":"url", "key":"db.url", "default":"localhost:3306"})
+func NewDb(c *Config, url string) (*Db, error) {
+	if len(url) == 0 {
+		return nil, errors.New("db url is empty")
+	}
+	return &Db{Url: c.Name + "@" + url}, nil
+}
+
+// @provider({"id":"golden.cache", "lazy":true})
+// @inject({"param":"digo", "id":"golden.db"})
+func NewCache(digo *Db) *Db {
+	return digo
+}
+
+type Request struct {
+	Env string
+	Db  *Db
+}
+
+// @provider({"id":"golden.request", "scope":"prototype"})
+// @env({"param":"env_vars", "name":"GOLDEN_ENV", "default":"dev"})
+// @inject({"param":"err", "id":"golden.db"})
+func NewRequest(env_vars string, err *Db) *Request {
+	return &Request{Env: env_vars, Db: err}
+}
+
+type Mailer interface {
+	Send(to string) error
+}
+
+type FakeMailer struct{}
+
+func (m *FakeMailer) Send(to string) error {
+	return nil
+}
+
+// @provider({"id":"golden.mailer", "profile":"dev"})
+func NewFakeMailer() Mailer {
+	return &FakeMailer{}
+}
+
+// @provider({"id":"golden.mailer", "profile":"prod"})
+// @inject({"param":"obj", "id":"golden.config"})
+func NewSmtpMailer(obj *Config) Mailer {
+	return &FakeMailer{}
+}
+
+type Handler interface {
+	Name() string
+}
+
+type UserHandler struct {
+	config *Config
+}
+
+func (h *UserHandler) Name() string {
+	return h.config.Name
+}
+
+// @group({"id":"golden.handlers", "type":"Handler", "name":"user"})
+// @inject({"param":"member", "id":"golden.config"})
+func NewUserHandler(member *Config) Handler {
+	return &UserHandler{config: member}
+}
+
+type Router struct {
+	Handlers []Handler
+	Cache    *Db
+	Mailer   Mailer
+}
+
+// @provider({"id":"golden.router"})
+// @inject({"param":"c", "group":"golden.handlers"})
+// @inject({"param":"member", "id":"golden.missing", "optional":true})
+// @inject({"param":"mailer", "id":"golden.mailer"})
+func NewRouter(c []Handler, member *Db, mailer Mailer) *Router {
+	return &Router{Handlers: c, Cache: member, Mailer: mailer}
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigSource provides the configuration values injected by the @value annotations, e.g. from a configuration
+// file, a remote configuration center or the environment variables.
+// ConfigSource 提供@value注解注入的配置项，比如来自配置文件、远程配置中心或者环境变量
+type ConfigSource interface {
+	// Lookup returns the raw value of the key and whether the key exists.
+	Lookup(key string) (string, bool)
+}
+
+// ConfigSourceFunc is an adapter to allow the use of ordinary functions as a ConfigSource,
+// e.g. digo.ConfigSourceFunc(os.LookupEnv).
+// ConfigSourceFunc 是一个适配器，允许将普通函数作为ConfigSource使用，比如digo.ConfigSourceFunc(os.LookupEnv)
+type ConfigSourceFunc func(key string) (string, bool)
+
+// Lookup calls f(key).
+func (f ConfigSourceFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// MapSource is a ConfigSource which looks up the values in a map.
+// MapSource 是从map中查找配置项的ConfigSource
+type MapSource map[string]string
+
+// Lookup returns the value of the key in the map.
+func (m MapSource) Lookup(key string) (string, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+// durationType is the reflect.Type of time.Duration, which is converted by time.ParseDuration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// SetConfigSource sets the source of the configuration values injected into the providers of the container,
+// the child scopes use the source of their parents unless they have their own.
+// The values are resolved when the providers are created, so the source must be set before the eager singletons
+// are registered, e.g. in an init() function of a package initialized before them, or the providers must be lazy.
+// SetConfigSource 设置注入到容器的provider中的配置项的来源，子作用域如果没有设置则使用父容器的配置源。
+// 配置项在创建provider的时候解析，所以必须在注册非延迟创建的单例之前设置配置源，比如在比它们先初始化的包的init()函数中，
+// 或者将provider设置为延迟创建
+func (c *Container) SetConfigSource(source ConfigSource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.source = source
+}
+
+// configSource returns the configuration source of the container or its parents, nil if none is set.
+// configSource 返回容器或者其父容器的配置源，没有设置则返回nil
+func (c *Container) configSource() ConfigSource {
+	for s := c; s != nil; s = s.parent {
+		s.mu.RLock()
+		source := s.source
+		s.mu.RUnlock()
+		if source != nil {
+			return source
+		}
+	}
+	return nil
+}
+
+// ValueFrom looks up the configuration value of the key in the configuration source of the container c,
+// and converts it to type T. The first default is used if the key is missing, supported types are strings,
+// booleans, integers, floats, time.Duration and slices of them, whose elements are separated by commas.
+// It returns a *ValueError naming the key if the value is missing and has no default, or cannot be converted.
+// ValueFrom 从容器c的配置源中查找指定key的配置项，并将其转换为T类型。如果key不存在则使用第一个默认值，
+// 支持的类型包括字符串、布尔值、整数、浮点数、time.Duration以及它们的切片，切片的元素以逗号分隔。
+// 如果配置项不存在且没有默认值，或者无法转换，则返回一个包含key的*ValueError
+func ValueFrom[T any](c *Container, key string, defaults ...string) (T, error) {
+	var zero T
+	value, ok := "", false
+	if source := c.configSource(); source != nil {
+		value, ok = source.Lookup(key)
+	}
+	if !ok && len(defaults) > 0 {
+		value, ok = defaults[0], true
+	}
+	if !ok {
+		return zero, &ValueError{Key: key, Err: ErrValueNotFound}
+	}
+
+	typ := typeOf[T]()
+	converted, err := convertValue(value, typ)
+	if err != nil {
+		return zero, &ValueError{Key: key, Value: value, Type: typ, Err: err}
+	}
+	return converted.Interface().(T), nil
+}
+
+// ValueOf looks up the configuration value of the key in the configuration source of the default container,
+// and converts it to type T.
+// ValueOf 从默认容器的配置源中查找指定key的配置项，并将其转换为T类型
+func ValueOf[T any](key string, defaults ...string) (T, error) {
+	return ValueFrom[T](defaultContainer, key, defaults...)
+}
+
+// SetConfigSource sets the source of the configuration values injected into the providers of the default container.
+func SetConfigSource(source ConfigSource) {
+	defaultContainer.SetConfigSource(source)
+}
+
+// convertValue converts the raw value to the type typ.
+// convertValue 将原始的配置值转换为typ类型
+func convertValue(value string, typ reflect.Type) (reflect.Value, error) {
+	result := reflect.New(typ).Elem()
+	switch {
+	case typ == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return result, err
+		}
+		result.SetInt(int64(d))
+		return result, nil
+	case typ.Kind() == reflect.Slice:
+		// An empty value is an empty slice, the elements are separated by commas.
+		// 空值表示空切片，元素之间以逗号分隔
+		fields := make([]string, 0)
+		if len(strings.TrimSpace(value)) > 0 {
+			fields = strings.Split(value, ",")
+		}
+		slice := reflect.MakeSlice(typ, len(fields), len(fields))
+		for i, field := range fields {
+			elem, err := convertValue(strings.TrimSpace(field), typ.Elem())
+			if err != nil {
+				return result, err
+			}
+			slice.Index(i).Set(elem)
+		}
+		return slice, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		result.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return result, err
+		}
+		result.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, typ.Bits())
+		if err != nil {
+			return result, err
+		}
+		result.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, typ.Bits())
+		if err != nil {
+			return result, err
+		}
+		result.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, typ.Bits())
+		if err != nil {
+			return result, err
+		}
+		result.SetFloat(f)
+	default:
+		return result, fmt.Errorf("unsupported type %s", typ)
+	}
+	return result, nil
+}
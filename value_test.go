@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2023 werbenhu
+// SPDX-FileContributor: werbenhu
+
+package digo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLevel string
+
+func TestValueFrom(t *testing.T) {
+	c := NewContainer()
+	c.SetConfigSource(MapSource{
+		"db.url":     "mysql:3306",
+		"db.port":    "3306",
+		"db.debug":   "true",
+		"db.timeout": "1m30s",
+		"db.hosts":   "a, b,c",
+		"db.ports":   "1,2",
+		"db.ratio":   "0.5",
+		"db.level":   "info",
+		"db.empty":   "",
+	})
+
+	url, err := ValueFrom[string](c, "db.url", "localhost:3306")
+	assert.NoError(t, err)
+	assert.Equal(t, "mysql:3306", url)
+
+	port, err := ValueFrom[int](c, "db.port")
+	assert.NoError(t, err)
+	assert.Equal(t, 3306, port)
+
+	debug, err := ValueFrom[bool](c, "db.debug")
+	assert.NoError(t, err)
+	assert.True(t, debug)
+
+	timeout, err := ValueFrom[time.Duration](c, "db.timeout")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, timeout)
+
+	hosts, err := ValueFrom[[]string](c, "db.hosts")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, hosts)
+
+	ports, err := ValueFrom[[]uint16](c, "db.ports")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint16{1, 2}, ports)
+
+	ratio, err := ValueFrom[float64](c, "db.ratio")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, ratio)
+
+	level, err := ValueFrom[testLevel](c, "db.level")
+	assert.NoError(t, err)
+	assert.Equal(t, testLevel("info"), level)
+
+	empty, err := ValueFrom[[]string](c, "db.empty")
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+
+	// The child scopes use the source of their parents
+	url, err = ValueFrom[string](c.NewScope(), "db.url")
+	assert.NoError(t, err)
+	assert.Equal(t, "mysql:3306", url)
+}
+
+func TestValueFrom_Default(t *testing.T) {
+	c := NewContainer()
+
+	// The default is used if there is no source or the key is missing
+	port, err := ValueFrom[int](c, "db.port", "3306")
+	assert.NoError(t, err)
+	assert.Equal(t, 3306, port)
+
+	c.SetConfigSource(ConfigSourceFunc(func(key string) (string, bool) { return "", false }))
+	hosts, err := ValueFrom[[]string](c, "db.hosts", "a,b")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, hosts)
+}
+
+func TestValueFrom_Error(t *testing.T) {
+	c := NewContainer()
+	c.SetConfigSource(MapSource{"db.port": "abc", "db.ports": "1,x", "db.db": "{}"})
+
+	// The errors name the key
+	_, err := ValueFrom[string](c, "db.url")
+	assert.EqualError(t, err, "config value db.url: value not found")
+	assert.ErrorIs(t, err, ErrValueNotFound)
+
+	_, err = ValueFrom[int](c, "db.port", "3306")
+	assert.EqualError(t, err, `failed to convert config value db.port="abc" to int: strconv.ParseInt: parsing "abc": invalid syntax`)
+	var valueErr *ValueError
+	assert.ErrorAs(t, err, &valueErr)
+	assert.Equal(t, "db.port", valueErr.Key)
+
+	_, err = ValueFrom[[]int](c, "db.ports")
+	assert.EqualError(t, err, `failed to convert config value db.ports="1,x" to []int: strconv.ParseInt: parsing "x": invalid syntax`)
+
+	_, err = ValueFrom[map[string]string](c, "db.db")
+	assert.EqualError(t, err, `failed to convert config value db.db="{}" to map[string]string: unsupported type map[string]string`)
+
+	_, err = ValueOf[string]("nonexistent.key")
+	assert.ErrorIs(t, err, ErrValueNotFound)
+}